@@ -1,5 +1,6 @@
 package gpgme
 
+// #include <stdlib.h>
 // #include <string.h>
 // #include <gpgme.h>
 // #include <errno.h>
@@ -237,3 +238,14 @@ func (d *Data) Name() string {
 	runtime.KeepAlive(d)
 	return res
 }
+
+// SetName sets the associated filename. With EncryptArchive it is the base
+// directory of the files to archive, with DecryptArchive the directory the
+// archive is extracted into.
+func (d *Data) SetName(name string) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	err := handleError(C.gpgme_data_set_file_name(d.dh, cname))
+	runtime.KeepAlive(d)
+	return err
+}
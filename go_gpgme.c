@@ -8,6 +8,35 @@ void gogpgme_set_passphrase_cb(gpgme_ctx_t ctx, gpgme_passphrase_cb_t cb, uintpt
 	gpgme_set_passphrase_cb(ctx, cb, (void *)handle);
 }
 
+void gogpgme_set_progress_cb(gpgme_ctx_t ctx, uintptr_t handle) {
+	if (handle == 0) {
+		gpgme_set_progress_cb(ctx, NULL, NULL);
+		return;
+	}
+	gpgme_set_progress_cb(ctx, (gpgme_progress_cb_t) gogpgme_progressfunc, (void *)handle);
+}
+
+void gogpgme_set_status_cb(gpgme_ctx_t ctx, uintptr_t handle) {
+	if (handle == 0) {
+		gpgme_set_status_cb(ctx, NULL, NULL);
+		return;
+	}
+	gpgme_set_status_cb(ctx, (gpgme_status_cb_t) gogpgme_statusfunc, (void *)handle);
+}
+
+gpgme_error_t gogpgme_op_interact(gpgme_ctx_t ctx, gpgme_key_t key, unsigned int flags, uintptr_t handle, gpgme_data_t out) {
+	return gpgme_op_interact(ctx, key, flags, (gpgme_interact_cb_t) gogpgme_interactfunc, (void *)handle, out);
+}
+
+/* gpgme_op_setownertrust was added in GPGME 1.24. */
+gpgme_error_t gogpgme_op_setownertrust(gpgme_ctx_t ctx, gpgme_key_t key, char *value) {
+#if GPGME_VERSION_NUMBER >= 0x011800
+	return gpgme_op_setownertrust(ctx, key, value);
+#else
+	return gpgme_error(GPG_ERR_NOT_IMPLEMENTED);
+#endif
+}
+
 gpgme_off_t gogpgme_data_seek(gpgme_data_t dh, gpgme_off_t offset, int whence) {
 	return gpgme_data_seek(dh, offset, whence);
 }
@@ -70,6 +99,70 @@ unsigned int key_is_qualified(gpgme_key_t k) {
 	return k->is_qualified;
 }
 
+unsigned int key_origin(gpgme_key_t k) {
+	return k->origin;
+}
+
+/* The has_* flags were added in GPGME 1.23; older versions derive them from
+   the usable subkeys. */
+#if GPGME_VERSION_NUMBER < 0x011700
+static int key_usable(gpgme_key_t k) {
+	return !k->revoked && !k->expired && !k->disabled && !k->invalid;
+}
+
+static int subkey_usable(gpgme_subkey_t s) {
+	return !s->revoked && !s->expired && !s->disabled && !s->invalid;
+}
+#endif
+
+unsigned int key_has_encrypt(gpgme_key_t k) {
+#if GPGME_VERSION_NUMBER >= 0x011700
+	return k->has_encrypt;
+#else
+	gpgme_subkey_t s;
+	for (s = k->subkeys; s && key_usable(k); s = s->next)
+		if (s->can_encrypt && subkey_usable(s))
+			return 1;
+	return 0;
+#endif
+}
+
+unsigned int key_has_sign(gpgme_key_t k) {
+#if GPGME_VERSION_NUMBER >= 0x011700
+	return k->has_sign;
+#else
+	gpgme_subkey_t s;
+	for (s = k->subkeys; s && key_usable(k); s = s->next)
+		if (s->can_sign && subkey_usable(s))
+			return 1;
+	return 0;
+#endif
+}
+
+unsigned int key_has_certify(gpgme_key_t k) {
+#if GPGME_VERSION_NUMBER >= 0x011700
+	return k->has_certify;
+#else
+	gpgme_subkey_t s;
+	for (s = k->subkeys; s && key_usable(k); s = s->next)
+		if (s->can_certify && subkey_usable(s))
+			return 1;
+	return 0;
+#endif
+}
+
+unsigned int key_has_authenticate(gpgme_key_t k) {
+#if GPGME_VERSION_NUMBER >= 0x011700
+	return k->has_authenticate;
+#else
+	gpgme_subkey_t s;
+	for (s = k->subkeys; s && key_usable(k); s = s->next)
+		if (s->can_authenticate && subkey_usable(s))
+			return 1;
+	return 0;
+#endif
+}
+
 unsigned int signature_wrong_key_usage(gpgme_signature_t s) {
     return s->wrong_key_usage;
 }
@@ -82,6 +175,18 @@ unsigned int signature_chain_model(gpgme_signature_t s) {
     return s->chain_model;
 }
 
+unsigned int decrypt_result_wrong_key_usage(gpgme_decrypt_result_t r) {
+	return r->wrong_key_usage;
+}
+
+unsigned int decrypt_result_is_mime(gpgme_decrypt_result_t r) {
+	return r->is_mime;
+}
+
+unsigned int decrypt_result_legacy_cipher_nomdc(gpgme_decrypt_result_t r) {
+	return r->legacy_cipher_nomdc;
+}
+
 unsigned int subkey_revoked(gpgme_subkey_t k) {
 	return k->revoked;
 }
@@ -102,6 +207,60 @@ unsigned int subkey_secret(gpgme_subkey_t k) {
 	return k->secret;
 }
 
+unsigned int subkey_can_encrypt(gpgme_subkey_t k) {
+	return k->can_encrypt;
+}
+
+unsigned int subkey_can_sign(gpgme_subkey_t k) {
+	return k->can_sign;
+}
+
+unsigned int subkey_can_certify(gpgme_subkey_t k) {
+	return k->can_certify;
+}
+
+unsigned int subkey_can_authenticate(gpgme_subkey_t k) {
+	return k->can_authenticate;
+}
+
+unsigned int subkey_is_qualified(gpgme_subkey_t k) {
+	return k->is_qualified;
+}
+
+unsigned int subkey_is_cardkey(gpgme_subkey_t k) {
+	return k->is_cardkey;
+}
+
+unsigned int subkey_is_de_vs(gpgme_subkey_t k) {
+	return k->is_de_vs;
+}
+
+/* can_renc, can_timestamp and is_group_owned were added in GPGME 1.23. */
+
+unsigned int subkey_can_renc(gpgme_subkey_t k) {
+#if GPGME_VERSION_NUMBER >= 0x011700
+	return k->can_renc;
+#else
+	return 0;
+#endif
+}
+
+unsigned int subkey_can_timestamp(gpgme_subkey_t k) {
+#if GPGME_VERSION_NUMBER >= 0x011700
+	return k->can_timestamp;
+#else
+	return 0;
+#endif
+}
+
+unsigned int subkey_is_group_owned(gpgme_subkey_t k) {
+#if GPGME_VERSION_NUMBER >= 0x011700
+	return k->is_group_owned;
+#else
+	return 0;
+#endif
+}
+
 unsigned int uid_revoked(gpgme_user_id_t u) {
 	return u->revoked;
 }
@@ -109,3 +268,51 @@ unsigned int uid_revoked(gpgme_user_id_t u) {
 unsigned int uid_invalid(gpgme_user_id_t u) {
 	return u->invalid;
 }
+
+unsigned int uid_origin(gpgme_user_id_t u) {
+	return u->origin;
+}
+
+unsigned int tofu_info_validity(gpgme_tofu_info_t t) {
+	return t->validity;
+}
+
+unsigned int tofu_info_policy(gpgme_tofu_info_t t) {
+	return t->policy;
+}
+
+unsigned int key_sig_revoked(gpgme_key_sig_t s) {
+	return s->revoked;
+}
+
+unsigned int key_sig_expired(gpgme_key_sig_t s) {
+	return s->expired;
+}
+
+unsigned int key_sig_invalid(gpgme_key_sig_t s) {
+	return s->invalid;
+}
+
+unsigned int key_sig_exportable(gpgme_key_sig_t s) {
+	return s->exportable;
+}
+
+unsigned int key_sig_trust_depth(gpgme_key_sig_t s) {
+	return s->trust_depth;
+}
+
+unsigned int key_sig_trust_value(gpgme_key_sig_t s) {
+	return s->trust_value;
+}
+
+unsigned int genkey_result_primary(gpgme_genkey_result_t r) {
+	return r->primary;
+}
+
+unsigned int genkey_result_sub(gpgme_genkey_result_t r) {
+	return r->sub;
+}
+
+unsigned int genkey_result_uid(gpgme_genkey_result_t r) {
+	return r->uid;
+}
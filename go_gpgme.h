@@ -6,12 +6,31 @@
 
 #include <gpgme.h>
 
+/* Flags missing from older GPGME headers. Their use is checked against the
+   version of the linked library at runtime. */
+#if GPGME_VERSION_NUMBER < 0x011300
+#define GPGME_ENCRYPT_ARCHIVE 512
+#define GPGME_DECRYPT_ARCHIVE 2
+#endif
+
+/* Public key algorithms missing from older GPGME headers. */
+#if GPGME_VERSION_NUMBER < 0x011800
+#define GPGME_PK_KYBER 8
+#endif
+
 extern ssize_t gogpgme_readfunc(void *handle, void *buffer, size_t size);
 extern ssize_t gogpgme_writefunc(void *handle, void *buffer, size_t size);
 extern off_t gogpgme_seekfunc(void *handle, off_t offset, int whence);
 extern gpgme_error_t gogpgme_passfunc(void *hook, char *uid_hint, char *passphrase_info, int prev_was_bad, int fd);
 extern gpgme_error_t gogpgme_data_new_from_cbs(gpgme_data_t *dh, gpgme_data_cbs_t cbs, uintptr_t handle);
 extern void gogpgme_set_passphrase_cb(gpgme_ctx_t ctx, gpgme_passphrase_cb_t cb, uintptr_t handle);
+extern void gogpgme_progressfunc(void *opaque, char *what, int type, int current, int total);
+extern void gogpgme_set_progress_cb(gpgme_ctx_t ctx, uintptr_t handle);
+extern gpgme_error_t gogpgme_statusfunc(void *opaque, char *keyword, char *args);
+extern void gogpgme_set_status_cb(gpgme_ctx_t ctx, uintptr_t handle);
+extern gpgme_error_t gogpgme_interactfunc(void *opaque, char *status, char *args, int fd);
+extern gpgme_error_t gogpgme_op_interact(gpgme_ctx_t ctx, gpgme_key_t key, unsigned int flags, uintptr_t handle, gpgme_data_t out);
+extern gpgme_error_t gogpgme_op_setownertrust(gpgme_ctx_t ctx, gpgme_key_t key, char *value);
 extern gpgme_off_t gogpgme_data_seek(gpgme_data_t dh, gpgme_off_t offset, int whence);
 
 extern gpgme_error_t gogpgme_op_assuan_transact_ext(gpgme_ctx_t ctx, char *cmd, uintptr_t data_h, uintptr_t inquiry_h , uintptr_t status_h, gpgme_error_t *operr);
@@ -30,15 +49,45 @@ extern unsigned int key_can_certify(gpgme_key_t k);
 extern unsigned int key_secret(gpgme_key_t k);
 extern unsigned int key_can_authenticate(gpgme_key_t k);
 extern unsigned int key_is_qualified(gpgme_key_t k);
+extern unsigned int key_origin(gpgme_key_t k);
+extern unsigned int key_has_encrypt(gpgme_key_t k);
+extern unsigned int key_has_sign(gpgme_key_t k);
+extern unsigned int key_has_certify(gpgme_key_t k);
+extern unsigned int key_has_authenticate(gpgme_key_t k);
 extern unsigned int signature_wrong_key_usage(gpgme_signature_t s);
 extern unsigned int signature_pka_trust(gpgme_signature_t s);
 extern unsigned int signature_chain_model(gpgme_signature_t s);
+extern unsigned int decrypt_result_wrong_key_usage(gpgme_decrypt_result_t r);
+extern unsigned int decrypt_result_is_mime(gpgme_decrypt_result_t r);
+extern unsigned int decrypt_result_legacy_cipher_nomdc(gpgme_decrypt_result_t r);
 extern unsigned int subkey_revoked(gpgme_subkey_t k);
 extern unsigned int subkey_expired(gpgme_subkey_t k);
 extern unsigned int subkey_disabled(gpgme_subkey_t k);
 extern unsigned int subkey_invalid(gpgme_subkey_t k);
 extern unsigned int subkey_secret(gpgme_subkey_t k);
+extern unsigned int subkey_can_encrypt(gpgme_subkey_t k);
+extern unsigned int subkey_can_sign(gpgme_subkey_t k);
+extern unsigned int subkey_can_certify(gpgme_subkey_t k);
+extern unsigned int subkey_can_authenticate(gpgme_subkey_t k);
+extern unsigned int subkey_is_qualified(gpgme_subkey_t k);
+extern unsigned int subkey_is_cardkey(gpgme_subkey_t k);
+extern unsigned int subkey_is_de_vs(gpgme_subkey_t k);
+extern unsigned int subkey_can_renc(gpgme_subkey_t k);
+extern unsigned int subkey_can_timestamp(gpgme_subkey_t k);
+extern unsigned int subkey_is_group_owned(gpgme_subkey_t k);
 extern unsigned int uid_revoked(gpgme_user_id_t u);
 extern unsigned int uid_invalid(gpgme_user_id_t u);
+extern unsigned int uid_origin(gpgme_user_id_t u);
+extern unsigned int tofu_info_validity(gpgme_tofu_info_t t);
+extern unsigned int tofu_info_policy(gpgme_tofu_info_t t);
+extern unsigned int key_sig_revoked(gpgme_key_sig_t s);
+extern unsigned int key_sig_expired(gpgme_key_sig_t s);
+extern unsigned int key_sig_invalid(gpgme_key_sig_t s);
+extern unsigned int key_sig_exportable(gpgme_key_sig_t s);
+extern unsigned int key_sig_trust_depth(gpgme_key_sig_t s);
+extern unsigned int key_sig_trust_value(gpgme_key_sig_t s);
+extern unsigned int genkey_result_primary(gpgme_genkey_result_t r);
+extern unsigned int genkey_result_sub(gpgme_genkey_result_t r);
+extern unsigned int genkey_result_uid(gpgme_genkey_result_t r);
 
 #endif
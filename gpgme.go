@@ -8,10 +8,13 @@ package gpgme
 // #include "go_gpgme.h"
 import "C"
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 	"unsafe"
 )
@@ -22,6 +25,13 @@ func init() {
 	Version = C.GoString(C.gpgme_check_version(nil))
 }
 
+// versionAtLeast reports whether the linked GPGME is at least version req.
+func versionAtLeast(req string) bool {
+	creq := C.CString(req)
+	defer C.free(unsafe.Pointer(creq))
+	return C.gpgme_check_version(creq) != nil
+}
+
 // Callback is the function that is called when a passphrase is required
 type Callback func(uidHint string, prevWasBad bool, f *os.File) error
 
@@ -38,6 +48,35 @@ func gogpgme_passfunc(hook unsafe.Pointer, uid_hint, passphrase_info *C.char, pr
 	return 0
 }
 
+// ProgressCallback is the function that is called to report the progress of
+// an operation. total is 0 if the total amount of work is unknown.
+type ProgressCallback func(what string, typ int, current, total int64)
+
+//export gogpgme_progressfunc
+func gogpgme_progressfunc(hook unsafe.Pointer, what *C.char, typ, current, total C.int) {
+	c := callbackLookup(uintptr(hook)).(*Context)
+	if c.progressCallback == nil {
+		return
+	}
+	c.progressCallback(C.GoString(what), int(typ), int64(current), int64(total))
+}
+
+// StatusCallback is the function that is called for every status line emitted
+// by the engine. Returning an error aborts the operation.
+type StatusCallback func(keyword, args string) error
+
+//export gogpgme_statusfunc
+func gogpgme_statusfunc(hook unsafe.Pointer, keyword, args *C.char) C.gpgme_error_t {
+	c := callbackLookup(uintptr(hook)).(*Context)
+	if c.statusCallback == nil {
+		return 0
+	}
+	if err := c.statusCallback(C.GoString(keyword), C.GoString(args)); err != nil {
+		return C.gpgme_error(C.GPG_ERR_USER_1)
+	}
+	return 0
+}
+
 type Protocol int
 
 const (
@@ -67,12 +106,98 @@ const (
 	EncryptAlwaysTrust EncryptFlag = C.GPGME_ENCRYPT_ALWAYS_TRUST
 	EncryptNoEncryptTo EncryptFlag = C.GPGME_ENCRYPT_NO_ENCRYPT_TO
 	EncryptPrepare     EncryptFlag = C.GPGME_ENCRYPT_PREPARE
-	EncryptExceptSign  EncryptFlag = C.GPGME_ENCRYPT_EXPECT_SIGN
+	EncryptExpectSign  EncryptFlag = C.GPGME_ENCRYPT_EXPECT_SIGN
+	EncryptNoCompress  EncryptFlag = C.GPGME_ENCRYPT_NO_COMPRESS
+	EncryptSymmetric   EncryptFlag = C.GPGME_ENCRYPT_SYMMETRIC
+	EncryptThrowKeyIDs EncryptFlag = C.GPGME_ENCRYPT_THROW_KEYIDS
+	EncryptWrap        EncryptFlag = C.GPGME_ENCRYPT_WRAP
+	EncryptWantAddress EncryptFlag = C.GPGME_ENCRYPT_WANT_ADDRESS
+	EncryptArchive     EncryptFlag = C.GPGME_ENCRYPT_ARCHIVE
+
+	// Deprecated: EncryptExceptSign is a misspelling of EncryptExpectSign.
+	EncryptExceptSign = EncryptExpectSign
+)
+
+// flagVersion records the GPGME version which introduced a flag
+type flagVersion struct {
+	flag    uint
+	name    string
+	version string
+}
+
+// checkFlagVersions returns an error if flags contains a value unknown to the
+// linked GPGME, which would otherwise silently ignore it.
+func checkFlagVersions(flags uint, versions []flagVersion) error {
+	for _, f := range versions {
+		if flags&f.flag != 0 && !versionAtLeast(f.version) {
+			return fmt.Errorf("%s requires GPGME %s or later, have %s", f.name, f.version, Version)
+		}
+	}
+	return nil
+}
+
+// encryptFlagVersions lists the EncryptFlag values that are not available in
+// all supported versions of GPGME.
+var encryptFlagVersions = []flagVersion{
+	{uint(EncryptNoCompress), "EncryptNoCompress", "1.5.0"},
+	{uint(EncryptSymmetric), "EncryptSymmetric", "1.7.0"},
+	{uint(EncryptThrowKeyIDs), "EncryptThrowKeyIDs", "1.8.0"},
+	{uint(EncryptWrap), "EncryptWrap", "1.11.0"},
+	{uint(EncryptWantAddress), "EncryptWantAddress", "1.11.0"},
+	{uint(EncryptArchive), "EncryptArchive", "1.19.0"},
+}
+
+func checkEncryptFlags(flags EncryptFlag) error {
+	return checkFlagVersions(uint(flags), encryptFlagVersions)
+}
+
+type DecryptFlags uint
+
+const (
+	DecryptVerify  DecryptFlags = C.GPGME_DECRYPT_VERIFY
+	DecryptUnwrap  DecryptFlags = C.GPGME_DECRYPT_UNWRAP
+	DecryptArchive DecryptFlags = C.GPGME_DECRYPT_ARCHIVE
 )
 
+// decryptFlagVersions lists the DecryptFlags values that are not available in
+// all supported versions of GPGME.
+var decryptFlagVersions = []flagVersion{
+	{uint(DecryptVerify), "DecryptVerify", "1.8.0"},
+	{uint(DecryptUnwrap), "DecryptUnwrap", "1.11.0"},
+	{uint(DecryptArchive), "DecryptArchive", "1.19.0"},
+}
+
+func checkDecryptFlags(flags DecryptFlags) error {
+	return checkFlagVersions(uint(flags), decryptFlagVersions)
+}
+
 type HashAlgo int
 
-// const values for HashAlgo values should be added when necessary.
+const (
+	HashAlgoNone         HashAlgo = C.GPGME_MD_NONE
+	HashAlgoMD5          HashAlgo = C.GPGME_MD_MD5
+	HashAlgoSHA1         HashAlgo = C.GPGME_MD_SHA1
+	HashAlgoRMD160       HashAlgo = C.GPGME_MD_RMD160
+	HashAlgoMD2          HashAlgo = C.GPGME_MD_MD2
+	HashAlgoTiger        HashAlgo = C.GPGME_MD_TIGER
+	HashAlgoHaval        HashAlgo = C.GPGME_MD_HAVAL
+	HashAlgoSHA256       HashAlgo = C.GPGME_MD_SHA256
+	HashAlgoSHA384       HashAlgo = C.GPGME_MD_SHA384
+	HashAlgoSHA512       HashAlgo = C.GPGME_MD_SHA512
+	HashAlgoSHA224       HashAlgo = C.GPGME_MD_SHA224
+	HashAlgoMD4          HashAlgo = C.GPGME_MD_MD4
+	HashAlgoCRC32        HashAlgo = C.GPGME_MD_CRC32
+	HashAlgoCRC32RFC1510 HashAlgo = C.GPGME_MD_CRC32_RFC1510
+	HashAlgoCRC24RFC2440 HashAlgo = C.GPGME_MD_CRC24_RFC2440
+)
+
+// String returns the name of the algorithm as reported by GPGME, e.g. "SHA256".
+func (h HashAlgo) String() string {
+	if name := C.gpgme_hash_algo_name(C.gpgme_hash_algo_t(h)); name != nil {
+		return C.GoString(name)
+	}
+	return fmt.Sprintf("HashAlgo(%d)", int(h))
+}
 
 type KeyListMode uint
 
@@ -83,11 +208,32 @@ const (
 	KeyListModeSigNotations KeyListMode = C.GPGME_KEYLIST_MODE_SIG_NOTATIONS
 	KeyListModeEphemeral    KeyListMode = C.GPGME_KEYLIST_MODE_EPHEMERAL
 	KeyListModeModeValidate KeyListMode = C.GPGME_KEYLIST_MODE_VALIDATE
+	KeyListModeWithTOFU     KeyListMode = C.GPGME_KEYLIST_MODE_WITH_TOFU
 )
 
 type PubkeyAlgo int
 
-// const values for PubkeyAlgo values should be added when necessary.
+const (
+	PubkeyAlgoRSA   PubkeyAlgo = C.GPGME_PK_RSA
+	PubkeyAlgoRSAE  PubkeyAlgo = C.GPGME_PK_RSA_E
+	PubkeyAlgoRSAS  PubkeyAlgo = C.GPGME_PK_RSA_S
+	PubkeyAlgoElgE  PubkeyAlgo = C.GPGME_PK_ELG_E
+	PubkeyAlgoDSA   PubkeyAlgo = C.GPGME_PK_DSA
+	PubkeyAlgoECC   PubkeyAlgo = C.GPGME_PK_ECC
+	PubkeyAlgoElg   PubkeyAlgo = C.GPGME_PK_ELG
+	PubkeyAlgoECDSA PubkeyAlgo = C.GPGME_PK_ECDSA
+	PubkeyAlgoECDH  PubkeyAlgo = C.GPGME_PK_ECDH
+	PubkeyAlgoEdDSA PubkeyAlgo = C.GPGME_PK_EDDSA
+	PubkeyAlgoKyber PubkeyAlgo = C.GPGME_PK_KYBER // GPGME 1.24 or later
+)
+
+// String returns the name of the algorithm as reported by GPGME, e.g. "RSA".
+func (p PubkeyAlgo) String() string {
+	if name := C.gpgme_pubkey_algo_name(C.gpgme_pubkey_algo_t(p)); name != nil {
+		return C.GoString(name)
+	}
+	return fmt.Sprintf("PubkeyAlgo(%d)", int(p))
+}
 
 type SigMode int
 
@@ -124,11 +270,53 @@ const (
 	ValidityUltimate  Validity = C.GPGME_VALIDITY_ULTIMATE
 )
 
+// KeyOrigin describes where a key or user ID was obtained from.
+type KeyOrigin int
+
+const (
+	KeyOriginUnknown KeyOrigin = C.GPGME_KEYORG_UNKNOWN
+	KeyOriginKS      KeyOrigin = C.GPGME_KEYORG_KS
+	KeyOriginDANE    KeyOrigin = C.GPGME_KEYORG_DANE
+	KeyOriginWKD     KeyOrigin = C.GPGME_KEYORG_WKD
+	KeyOriginURL     KeyOrigin = C.GPGME_KEYORG_URL
+	KeyOriginFile    KeyOrigin = C.GPGME_KEYORG_FILE
+	KeyOriginSelf    KeyOrigin = C.GPGME_KEYORG_SELF
+	KeyOriginOther   KeyOrigin = C.GPGME_KEYORG_OTHER
+)
+
+// TofuValidity is the validity of a binding as computed by the TOFU trust
+// model.
+type TofuValidity int
+
+const (
+	TofuValidityConflict      TofuValidity = 0
+	TofuValidityNoHistory     TofuValidity = 1
+	TofuValidityLittleHistory TofuValidity = 2
+	TofuValidityEnoughHistory TofuValidity = 3
+	TofuValidityLotsOfHistory TofuValidity = 4
+)
+
+type TofuPolicy int
+
+const (
+	TofuPolicyNone    TofuPolicy = C.GPGME_TOFU_POLICY_NONE
+	TofuPolicyAuto    TofuPolicy = C.GPGME_TOFU_POLICY_AUTO
+	TofuPolicyGood    TofuPolicy = C.GPGME_TOFU_POLICY_GOOD
+	TofuPolicyUnknown TofuPolicy = C.GPGME_TOFU_POLICY_UNKNOWN
+	TofuPolicyBad     TofuPolicy = C.GPGME_TOFU_POLICY_BAD
+	TofuPolicyAsk     TofuPolicy = C.GPGME_TOFU_POLICY_ASK
+)
+
 type ErrorCode int
 
 const (
-	ErrorNoError ErrorCode = C.GPG_ERR_NO_ERROR
-	ErrorEOF     ErrorCode = C.GPG_ERR_EOF
+	ErrorNoError       ErrorCode = C.GPG_ERR_NO_ERROR
+	ErrorEOF           ErrorCode = C.GPG_ERR_EOF
+	ErrorCanceled      ErrorCode = C.GPG_ERR_CANCELED
+	ErrorNoPublicKey   ErrorCode = C.GPG_ERR_NO_PUBKEY
+	ErrorConflict      ErrorCode = C.GPG_ERR_CONFLICT
+	ErrorAmbiguousName ErrorCode = C.GPG_ERR_AMBIGUOUS_NAME
+	ErrorNotSupported  ErrorCode = C.GPG_ERR_NOT_SUPPORTED
 )
 
 // Error is a wrapper for GPGME errors
@@ -268,7 +456,7 @@ func Decrypt(r io.Reader) (*Data, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ctx.Decrypt(cipher, plain); err != nil {
+	if _, err := ctx.Decrypt(cipher, plain); err != nil {
 		return nil, err
 	}
 	_, err = plain.Seek(0, SeekSet)
@@ -282,6 +470,14 @@ type Context struct {
 	callback Callback
 	cbc      uintptr // WARNING: Call runtime.KeepAlive(c) after ANY use of c.cbc in C (typically via c.ctx)
 
+	progressCallback ProgressCallback
+	progressCbc      uintptr // WARNING: Call runtime.KeepAlive(c) after ANY use of c.progressCbc in C (typically via c.ctx)
+
+	statusCallback StatusCallback
+	statusCbc      uintptr // WARNING: Call runtime.KeepAlive(c) after ANY use of c.statusCbc in C (typically via c.ctx)
+
+	pending []interface{} // keeps the arguments of an asynchronous operation alive until Wait returns
+
 	ctx C.gpgme_ctx_t // WARNING: Call runtime.KeepAlive(c) after ANY passing of c.ctx to C
 }
 
@@ -299,6 +495,12 @@ func (c *Context) Release() {
 	if c.cbc > 0 {
 		callbackDelete(c.cbc)
 	}
+	if c.progressCbc > 0 {
+		callbackDelete(c.progressCbc)
+	}
+	if c.statusCbc > 0 {
+		callbackDelete(c.statusCbc)
+	}
 	C.gpgme_release(c.ctx)
 	runtime.KeepAlive(c)
 	c.ctx = nil
@@ -326,6 +528,54 @@ func (c *Context) TextMode() bool {
 	return res
 }
 
+// SetOffline prevents the engine from accessing the network, e.g. for CRL
+// checks or key retrieval.
+func (c *Context) SetOffline(yes bool) {
+	C.gpgme_set_offline(c.ctx, cbool(yes))
+	runtime.KeepAlive(c)
+}
+
+func (c *Context) Offline() bool {
+	res := C.gpgme_get_offline(c.ctx) != 0
+	runtime.KeepAlive(c)
+	return res
+}
+
+// SetSender sets the mail address of the sender, which is used in signatures
+// and for key discovery. An empty address clears it.
+func (c *Context) SetSender(address string) error {
+	var caddr *C.char
+	if address != "" {
+		caddr = C.CString(address)
+		defer C.free(unsafe.Pointer(caddr))
+	}
+	err := handleError(C.gpgme_set_sender(c.ctx, caddr))
+	runtime.KeepAlive(c)
+	return err
+}
+
+func (c *Context) Sender() string {
+	res := C.GoString(C.gpgme_get_sender(c.ctx))
+	runtime.KeepAlive(c)
+	return res
+}
+
+// IncludeCertsDefault selects the engine's default for SetIncludeCerts
+const IncludeCertsDefault = C.GPGME_INCLUDE_CERTS_DEFAULT
+
+// SetIncludeCerts sets how many certificates of the chain are included in CMS
+// signatures; see gpgme_set_include_certs for the special values.
+func (c *Context) SetIncludeCerts(n int) {
+	C.gpgme_set_include_certs(c.ctx, C.int(n))
+	runtime.KeepAlive(c)
+}
+
+func (c *Context) IncludeCerts() int {
+	res := int(C.gpgme_get_include_certs(c.ctx))
+	runtime.KeepAlive(c)
+	return res
+}
+
 func (c *Context) SetProtocol(p Protocol) error {
 	err := handleError(C.gpgme_set_protocol(c.ctx, C.gpgme_protocol_t(p)))
 	runtime.KeepAlive(c)
@@ -380,6 +630,159 @@ func (c *Context) SetCallback(callback Callback) error {
 	return err
 }
 
+// SetFlag sets the context flag name to value, see gpgme_set_ctx_flag for the
+// flags known to GPGME.
+func (c *Context) SetFlag(name, value string) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	err := handleError(C.gpgme_set_ctx_flag(c.ctx, cname, cvalue))
+	runtime.KeepAlive(c)
+	return err
+}
+
+// Flag returns the value of the context flag name, or "" if it is not set or
+// unknown to GPGME.
+func (c *Context) Flag(name string) string {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	res := C.GoString(C.gpgme_get_ctx_flag(c.ctx, cname))
+	runtime.KeepAlive(c)
+	return res
+}
+
+func (c *Context) setBoolFlag(name string, yes bool) error {
+	if yes {
+		return c.SetFlag(name, "1")
+	}
+	return c.SetFlag(name, "0")
+}
+
+func (c *Context) boolFlag(name string) bool {
+	v := c.Flag(name)
+	return v != "" && v != "0"
+}
+
+// SetTrustModel overrides the trust model of the engine, e.g. "tofu+pgp".
+func (c *Context) SetTrustModel(model string) error {
+	return c.SetFlag("trust-model", model)
+}
+
+func (c *Context) TrustModel() string {
+	return c.Flag("trust-model")
+}
+
+// SetAutoKeyRetrieve enables retrieving missing keys while verifying signatures.
+func (c *Context) SetAutoKeyRetrieve(yes bool) error {
+	return c.setBoolFlag("auto-key-retrieve", yes)
+}
+
+func (c *Context) AutoKeyRetrieve() bool {
+	return c.boolFlag("auto-key-retrieve")
+}
+
+// SetNoSymKeyCache disables the passphrase cache for symmetric encryption.
+func (c *Context) SetNoSymKeyCache(yes bool) error {
+	return c.setBoolFlag("no-symkey-cache", yes)
+}
+
+func (c *Context) NoSymKeyCache() bool {
+	return c.boolFlag("no-symkey-cache")
+}
+
+// SetRequestOrigin tells gpg-agent where requests originate from, one of
+// "none", "local", "remote" or "browser".
+func (c *Context) SetRequestOrigin(origin string) error {
+	return c.SetFlag("request-origin", origin)
+}
+
+func (c *Context) RequestOrigin() string {
+	return c.Flag("request-origin")
+}
+
+// SetIncludeKeyBlock embeds the signer's public key in created signatures.
+func (c *Context) SetIncludeKeyBlock(yes bool) error {
+	return c.setBoolFlag("include-key-block", yes)
+}
+
+func (c *Context) IncludeKeyBlock() bool {
+	return c.boolFlag("include-key-block")
+}
+
+// SetIgnoreMDCError makes decryption succeed despite a missing or broken
+// integrity check. This is insecure and only meant for recovering old data.
+func (c *Context) SetIgnoreMDCError(yes bool) error {
+	return c.setBoolFlag("ignore-mdc-error", yes)
+}
+
+func (c *Context) IgnoreMDCError() bool {
+	return c.boolFlag("ignore-mdc-error")
+}
+
+// SetOverrideSessionKey decrypts with sessionKey, as reported in
+// DecryptResult.SessionKey, instead of a secret key.
+func (c *Context) SetOverrideSessionKey(sessionKey string) error {
+	return c.SetFlag("override-session-key", sessionKey)
+}
+
+func (c *Context) OverrideSessionKey() string {
+	return c.Flag("override-session-key")
+}
+
+// SetExportSessionKey makes decryption report the session key in
+// DecryptResult.SessionKey.
+func (c *Context) SetExportSessionKey(yes bool) error {
+	return c.setBoolFlag("export-session-key", yes)
+}
+
+func (c *Context) ExportSessionKey() bool {
+	return c.boolFlag("export-session-key")
+}
+
+// SetProgressCallback sets the function that is called to report progress of
+// operations. A nil callback disables progress reporting.
+func (c *Context) SetProgressCallback(callback ProgressCallback) {
+	c.progressCallback = callback
+	if c.progressCbc > 0 {
+		callbackDelete(c.progressCbc)
+	}
+	if callback != nil {
+		cbc := callbackAdd(c)
+		c.progressCbc = cbc
+		C.gogpgme_set_progress_cb(c.ctx, C.uintptr_t(cbc))
+	} else {
+		c.progressCbc = 0
+		C.gogpgme_set_progress_cb(c.ctx, 0)
+	}
+	runtime.KeepAlive(c)
+}
+
+// SetStatusCallback sets the function that is called with the raw status
+// lines of the engine. Setting a callback enables the "full-status" flag so
+// that status lines GPGME handles itself are passed on as well; a nil callback
+// disables both.
+func (c *Context) SetStatusCallback(callback StatusCallback) error {
+	if err := c.setBoolFlag("full-status", callback != nil); err != nil {
+		return err
+	}
+
+	c.statusCallback = callback
+	if c.statusCbc > 0 {
+		callbackDelete(c.statusCbc)
+	}
+	if callback != nil {
+		cbc := callbackAdd(c)
+		c.statusCbc = cbc
+		C.gogpgme_set_status_cb(c.ctx, C.uintptr_t(cbc))
+	} else {
+		c.statusCbc = 0
+		C.gogpgme_set_status_cb(c.ctx, 0)
+	}
+	runtime.KeepAlive(c)
+	return nil
+}
+
 func (c *Context) EngineInfo() *EngineInfo {
 	cInfo := C.gpgme_ctx_get_engine_info(c.ctx)
 	runtime.KeepAlive(c)
@@ -452,26 +855,126 @@ func (c *Context) GetKey(fingerprint string, secret bool) (*Key, error) {
 	return key, nil
 }
 
-func (c *Context) Decrypt(ciphertext, plaintext *Data) error {
+// Recipient describes a key a message was encrypted to
+type Recipient struct {
+	KeyID      string
+	PubkeyAlgo PubkeyAlgo
+	Status     error
+}
+
+// DecryptResult describes the outcome of Decrypt and DecryptVerify.
+// SessionKey is only set if exporting session keys is enabled on the Context.
+type DecryptResult struct {
+	UnsupportedAlgorithm string
+	WrongKeyUsage        bool
+	IsMIME               bool
+	LegacyCipherNoMDC    bool
+	Recipients           []Recipient
+	FileName             string
+	SessionKey           string
+	SymKeyAlgo           string
+}
+
+// DecryptResult returns the result of the last decryption operation.
+func (c *Context) DecryptResult() *DecryptResult {
+	res := C.gpgme_op_decrypt_result(c.ctx)
+	runtime.KeepAlive(c)
+	if res == nil {
+		return nil
+	}
+	// NOTE: c must be live as long as we are accessing res.
+	recipients := []Recipient{}
+	for r := res.recipients; r != nil; r = r.next {
+		recipients = append(recipients, Recipient{
+			KeyID:      C.GoString(r.keyid),
+			PubkeyAlgo: PubkeyAlgo(r.pubkey_algo),
+			Status:     handleError(r.status),
+		})
+	}
+	decryptResult := &DecryptResult{
+		UnsupportedAlgorithm: C.GoString(res.unsupported_algorithm),
+		WrongKeyUsage:        C.decrypt_result_wrong_key_usage(res) != 0,
+		IsMIME:               C.decrypt_result_is_mime(res) != 0,
+		LegacyCipherNoMDC:    C.decrypt_result_legacy_cipher_nomdc(res) != 0,
+		Recipients:           recipients,
+		FileName:             C.GoString(res.file_name),
+		SessionKey:           C.GoString(res.session_key),
+		SymKeyAlgo:           C.GoString(res.symkey_algo),
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return decryptResult
+}
+
+// Decrypt decrypts ciphertext into plaintext. The result is returned even if
+// the operation fails, so that e.g. unsupported algorithms can be reported.
+func (c *Context) Decrypt(ciphertext, plaintext *Data) (*DecryptResult, error) {
 	err := handleError(C.gpgme_op_decrypt(c.ctx, ciphertext.dh, plaintext.dh))
 	runtime.KeepAlive(c)
 	runtime.KeepAlive(ciphertext)
 	runtime.KeepAlive(plaintext)
-	return err
+	return c.DecryptResult(), err
 }
 
-func (c *Context) DecryptVerify(ciphertext, plaintext *Data) error {
+func (c *Context) DecryptVerify(ciphertext, plaintext *Data) (*DecryptResult, error) {
 	err := handleError(C.gpgme_op_decrypt_verify(c.ctx, ciphertext.dh, plaintext.dh))
 	runtime.KeepAlive(c)
 	runtime.KeepAlive(ciphertext)
 	runtime.KeepAlive(plaintext)
-	return err
+	return c.DecryptResult(), err
+}
+
+// DecryptExt decrypts ciphertext into plaintext as selected by flags. With
+// DecryptVerify, the signatures are available from VerifyResult. DecryptUnwrap
+// removes only the encryption layer and writes the signed OpenPGP message
+// to plaintext.
+func (c *Context) DecryptExt(flags DecryptFlags, ciphertext, plaintext *Data) (*DecryptResult, error) {
+	if err := checkDecryptFlags(flags); err != nil {
+		return nil, err
+	}
+	err := handleError(C.gpgme_op_decrypt_ext(c.ctx, C.gpgme_decrypt_flags_t(flags), ciphertext.dh, plaintext.dh))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(ciphertext)
+	runtime.KeepAlive(plaintext)
+	return c.DecryptResult(), err
+}
+
+// SigNotationFlags describes a signature notation
+type SigNotationFlags uint
+
+const (
+	SigNotationHumanReadable SigNotationFlags = C.GPGME_SIG_NOTATION_HUMAN_READABLE
+	SigNotationCritical      SigNotationFlags = C.GPGME_SIG_NOTATION_CRITICAL
+)
+
+// SigNotation is a notation or, if Name is empty, a policy URL attached to a
+// signature. The engine does not report whether a policy URL is critical, so
+// its Flags are always 0.
+type SigNotation struct {
+	Name  string
+	Value string
+	Flags SigNotationFlags
+}
+
+func copySigNotations(n C.gpgme_sig_notation_t) []SigNotation {
+	notations := []SigNotation{}
+	for ; n != nil; n = n.next {
+		notation := SigNotation{
+			Value: C.GoStringN(n.value, n.value_len),
+			Flags: SigNotationFlags(n.flags),
+		}
+		if n.name != nil {
+			notation.Name = C.GoStringN(n.name, n.name_len)
+		}
+		notations = append(notations, notation)
+	}
+	return notations
 }
 
 type Signature struct {
 	Summary        SigSum
 	Fingerprint    string
 	Status         error
+	Notations      []SigNotation
 	Timestamp      time.Time
 	ExpTimestamp   time.Time
 	WrongKeyUsage  bool
@@ -503,16 +1006,26 @@ func (c *Context) Verify(sig, signedText, plain *Data) (string, []Signature, err
 	if err != nil {
 		return "", nil, err
 	}
+	fileName, sigs := c.VerifyResult()
+	return fileName, sigs, nil
+}
+
+// VerifyResult returns the file name and signatures found by the last
+// verification operation.
+func (c *Context) VerifyResult() (string, []Signature) {
 	res := C.gpgme_op_verify_result(c.ctx)
 	runtime.KeepAlive(c)
+	if res == nil {
+		return "", nil
+	}
 	// NOTE: c must be live as long as we are accessing res.
 	sigs := []Signature{}
 	for s := res.signatures; s != nil; s = s.next {
 		sig := Signature{
-			Summary:     SigSum(s.summary),
-			Fingerprint: C.GoString(s.fpr),
-			Status:      handleError(s.status),
-			// s.notations not implemented
+			Summary:        SigSum(s.summary),
+			Fingerprint:    C.GoString(s.fpr),
+			Status:         handleError(s.status),
+			Notations:      copySigNotations(s.notations),
 			Timestamp:      time.Unix(int64(s.timestamp), 0),
 			ExpTimestamp:   time.Unix(int64(s.exp_timestamp), 0),
 			WrongKeyUsage:  C.signature_wrong_key_usage(s) != 0,
@@ -527,46 +1040,477 @@ func (c *Context) Verify(sig, signedText, plain *Data) (string, []Signature, err
 	}
 	fileName := C.GoString(res.file_name)
 	runtime.KeepAlive(c) // for all accesses to res above
-	return fileName, sigs, nil
+	return fileName, sigs
+}
+
+// InvalidKey describes a key that could not be used for an operation
+type InvalidKey struct {
+	Fingerprint string
+	Reason      error
+}
+
+func copyInvalidKeys(k C.gpgme_invalid_key_t) []InvalidKey {
+	keys := []InvalidKey{}
+	for ; k != nil; k = k.next {
+		keys = append(keys, InvalidKey{
+			Fingerprint: C.GoString(k.fpr),
+			Reason:      handleError(k.reason),
+		})
+	}
+	return keys
+}
+
+type EncryptResult struct {
+	InvalidRecipients []InvalidKey
+}
+
+// EncryptResult returns the result of the last encryption operation.
+func (c *Context) EncryptResult() *EncryptResult {
+	res := C.gpgme_op_encrypt_result(c.ctx)
+	runtime.KeepAlive(c)
+	if res == nil {
+		return nil
+	}
+	// NOTE: c must be live as long as we are accessing res.
+	encryptResult := &EncryptResult{
+		InvalidRecipients: copyInvalidKeys(res.invalid_recipients),
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return encryptResult
+}
+
+// NewSignature describes a signature created by Sign or EncryptSign
+type NewSignature struct {
+	Type        SigMode
+	PubkeyAlgo  PubkeyAlgo
+	HashAlgo    HashAlgo
+	Class       uint
+	Timestamp   time.Time
+	Fingerprint string
+}
+
+type SignResult struct {
+	InvalidSigners []InvalidKey
+	NewSignatures  []NewSignature
+}
+
+// SignResult returns the result of the last signing operation.
+func (c *Context) SignResult() *SignResult {
+	res := C.gpgme_op_sign_result(c.ctx)
+	runtime.KeepAlive(c)
+	if res == nil {
+		return nil
+	}
+	// NOTE: c must be live as long as we are accessing res.
+	sigs := []NewSignature{}
+	for s := res.signatures; s != nil; s = s.next {
+		sigs = append(sigs, NewSignature{
+			Type:        SigMode(s._type),
+			PubkeyAlgo:  PubkeyAlgo(s.pubkey_algo),
+			HashAlgo:    HashAlgo(s.hash_algo),
+			Class:       uint(s.sig_class),
+			Timestamp:   time.Unix(int64(s.timestamp), 0),
+			Fingerprint: C.GoString(s.fpr),
+		})
+	}
+	signResult := &SignResult{
+		InvalidSigners: copyInvalidKeys(res.invalid_signers),
+		NewSignatures:  sigs,
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return signResult
 }
 
-func (c *Context) Encrypt(recipients []*Key, flags EncryptFlag, plaintext, ciphertext *Data) error {
+// recipientArray returns recipients as a NULL-terminated C array which must be
+// released with C.free. The caller must keep recipients alive while it is used.
+// An empty list yields NULL, which GPGME takes as a request for symmetric
+// encryption.
+func recipientArray(recipients []*Key) unsafe.Pointer {
+	if len(recipients) == 0 {
+		return nil
+	}
 	size := unsafe.Sizeof(new(C.gpgme_key_t))
 	recp := C.calloc(C.size_t(len(recipients)+1), C.size_t(size))
-	defer C.free(recp)
 	for i := range recipients {
 		ptr := (*C.gpgme_key_t)(unsafe.Pointer(uintptr(recp) + size*uintptr(i)))
 		*ptr = recipients[i].k
 	}
-	err := C.gpgme_op_encrypt(c.ctx, (*C.gpgme_key_t)(recp), C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh)
+	return recp
+}
+
+// Encrypt encrypts plaintext to recipients. With no recipients, or with
+// EncryptSymmetric in flags, the data is also encrypted with a passphrase
+// obtained through the pinentry or Callback. The result is returned even if the
+// operation fails, so that unusable recipients can be identified.
+func (c *Context) Encrypt(recipients []*Key, flags EncryptFlag, plaintext, ciphertext *Data) (*EncryptResult, error) {
+	if err := checkEncryptFlags(flags); err != nil {
+		return nil, err
+	}
+	recp := recipientArray(recipients)
+	defer C.free(recp)
+	err := handleError(C.gpgme_op_encrypt(c.ctx, (*C.gpgme_key_t)(recp), C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
 	runtime.KeepAlive(c)
 	runtime.KeepAlive(recipients)
 	runtime.KeepAlive(plaintext)
 	runtime.KeepAlive(ciphertext)
-	return handleError(err)
+	return c.EncryptResult(), err
 }
 
-func (c *Context) Sign(signers []*Key, plain, sig *Data, mode SigMode) error {
-	C.gpgme_signers_clear(c.ctx)
-	runtime.KeepAlive(c)
-	for _, k := range signers {
-		err := handleError(C.gpgme_signers_add(c.ctx, k.k))
-		runtime.KeepAlive(c)
-		runtime.KeepAlive(k)
-		if err != nil {
-			C.gpgme_signers_clear(c.ctx)
-			runtime.KeepAlive(c)
-			return err
-		}
+// Options that may appear in the recipient list of EncryptExt and
+// EncryptSignExt. RecipientHidden and RecipientNoHidden apply to all following
+// recipients, RecipientFile and RecipientLiteral only to the next one.
+const (
+	RecipientHidden   = "--hidden"
+	RecipientNoHidden = "--no-hidden"
+	RecipientFile     = "--file"
+	RecipientLiteral  = "--"
+)
+
+// recipientString joins recipients into the line separated format expected by
+// GPGME, or returns NULL for an empty list. The result must be released with
+// C.free.
+func recipientString(recipients []string) *C.char {
+	if len(recipients) == 0 {
+		return nil
 	}
-	err := handleError(C.gpgme_op_sign(c.ctx, plain.dh, sig.dh, C.gpgme_sig_mode_t(mode)))
+	return C.CString(strings.Join(recipients, "\n"))
+}
+
+// EncryptExt is like Encrypt, but takes the recipients as fingerprints, key
+// IDs or mail addresses, interspersed with the Recipient options.
+func (c *Context) EncryptExt(recipients []string, flags EncryptFlag, plaintext, ciphertext *Data) (*EncryptResult, error) {
+	if err := checkEncryptFlags(flags); err != nil {
+		return nil, err
+	}
+	crecp := recipientString(recipients)
+	defer C.free(unsafe.Pointer(crecp))
+	err := handleError(C.gpgme_op_encrypt_ext(c.ctx, nil, crecp, C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
 	runtime.KeepAlive(c)
-	runtime.KeepAlive(plain)
-	runtime.KeepAlive(sig)
-	return err
+	runtime.KeepAlive(plaintext)
+	runtime.KeepAlive(ciphertext)
+	return c.EncryptResult(), err
 }
 
-type AssuanDataCallback func(data []byte) error
+// EncryptSignExt is like EncryptSign, but takes the recipients in the format
+// of EncryptExt.
+func (c *Context) EncryptSignExt(recipients []string, signers []*Key, flags EncryptFlag, plaintext, ciphertext *Data) (*EncryptResult, *SignResult, error) {
+	if err := checkEncryptFlags(flags); err != nil {
+		return nil, nil, err
+	}
+	if err := c.SetSigners(signers); err != nil {
+		return nil, nil, err
+	}
+	crecp := recipientString(recipients)
+	defer C.free(unsafe.Pointer(crecp))
+	err := handleError(C.gpgme_op_encrypt_sign_ext(c.ctx, nil, crecp, C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(plaintext)
+	runtime.KeepAlive(ciphertext)
+	return c.EncryptResult(), c.SignResult(), err
+}
+
+// EncryptSymmetric encrypts plaintext with a passphrase only. With GnuPG 2.1
+// and later, the passphrase is only requested through Callback if the pinentry
+// mode is PinEntryLoopback.
+func (c *Context) EncryptSymmetric(plaintext, ciphertext *Data) error {
+	_, err := c.Encrypt(nil, EncryptSymmetric, plaintext, ciphertext)
+	return err
+}
+
+// EncryptSign encrypts plaintext to recipients and signs it with signers in a
+// single pass. The results are returned even if the operation fails, so that
+// unusable recipients and signers can be identified.
+func (c *Context) EncryptSign(recipients, signers []*Key, flags EncryptFlag, plaintext, ciphertext *Data) (*EncryptResult, *SignResult, error) {
+	if err := checkEncryptFlags(flags); err != nil {
+		return nil, nil, err
+	}
+	if err := c.SetSigners(signers); err != nil {
+		return nil, nil, err
+	}
+	recp := recipientArray(recipients)
+	defer C.free(recp)
+	err := handleError(C.gpgme_op_encrypt_sign(c.ctx, (*C.gpgme_key_t)(recp), C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(recipients)
+	runtime.KeepAlive(plaintext)
+	runtime.KeepAlive(ciphertext)
+	return c.EncryptResult(), c.SignResult(), err
+}
+
+// SetSigners replaces the keys used for signing. An empty list selects the
+// engine's default key. Sign and EncryptSign call it with their signers, while
+// SignKey uses the current list.
+func (c *Context) SetSigners(signers []*Key) error {
+	C.gpgme_signers_clear(c.ctx)
+	runtime.KeepAlive(c)
+	for _, k := range signers {
+		err := handleError(C.gpgme_signers_add(c.ctx, k.k))
+		runtime.KeepAlive(c)
+		runtime.KeepAlive(k)
+		if err != nil {
+			C.gpgme_signers_clear(c.ctx)
+			runtime.KeepAlive(c)
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSigNotation adds a notation to the signatures created by Sign and
+// EncryptSign. An empty name adds value as a policy URL.
+func (c *Context) AddSigNotation(name, value string, flags SigNotationFlags) error {
+	var cname *C.char
+	if name != "" {
+		cname = C.CString(name)
+		defer C.free(unsafe.Pointer(cname))
+	}
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	err := handleError(C.gpgme_sig_notation_add(c.ctx, cname, cvalue, C.gpgme_sig_notation_flags_t(flags)))
+	runtime.KeepAlive(c)
+	return err
+}
+
+// ClearSigNotations removes all notations added with AddSigNotation.
+func (c *Context) ClearSigNotations() {
+	C.gpgme_sig_notation_clear(c.ctx)
+	runtime.KeepAlive(c)
+}
+
+// Sign signs plain with signers. The result is returned even if the operation
+// fails, so that unusable signers can be identified.
+func (c *Context) Sign(signers []*Key, plain, sig *Data, mode SigMode) (*SignResult, error) {
+	if err := c.SetSigners(signers); err != nil {
+		return nil, err
+	}
+	err := handleError(C.gpgme_op_sign(c.ctx, plain.dh, sig.dh, C.gpgme_sig_mode_t(mode)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(plain)
+	runtime.KeepAlive(sig)
+	return c.SignResult(), err
+}
+
+// DecryptStart starts an asynchronous Decrypt. Use Wait to complete it and
+// DecryptResult to retrieve its result.
+func (c *Context) DecryptStart(ciphertext, plaintext *Data) error {
+	err := handleError(C.gpgme_op_decrypt_start(c.ctx, ciphertext.dh, plaintext.dh))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return err
+	}
+	c.pending = []interface{}{ciphertext, plaintext}
+	return nil
+}
+
+// DecryptVerifyStart starts an asynchronous DecryptVerify. Use Wait to complete
+// it and DecryptResult and VerifyResult to retrieve its results.
+func (c *Context) DecryptVerifyStart(ciphertext, plaintext *Data) error {
+	err := handleError(C.gpgme_op_decrypt_verify_start(c.ctx, ciphertext.dh, plaintext.dh))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return err
+	}
+	c.pending = []interface{}{ciphertext, plaintext}
+	return nil
+}
+
+// VerifyStart starts an asynchronous Verify. Use Wait to complete it and
+// VerifyResult to retrieve its result.
+func (c *Context) VerifyStart(sig, signedText, plain *Data) error {
+	var signedTextPtr, plainPtr C.gpgme_data_t = nil, nil
+	if signedText != nil {
+		signedTextPtr = signedText.dh
+	}
+	if plain != nil {
+		plainPtr = plain.dh
+	}
+	err := handleError(C.gpgme_op_verify_start(c.ctx, sig.dh, signedTextPtr, plainPtr))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return err
+	}
+	c.pending = []interface{}{sig, signedText, plain}
+	return nil
+}
+
+// EncryptStart starts an asynchronous Encrypt. Use Wait to complete it and
+// EncryptResult to retrieve its result.
+func (c *Context) EncryptStart(recipients []*Key, flags EncryptFlag, plaintext, ciphertext *Data) error {
+	if err := checkEncryptFlags(flags); err != nil {
+		return err
+	}
+	recp := recipientArray(recipients)
+	defer C.free(recp)
+	err := handleError(C.gpgme_op_encrypt_start(c.ctx, (*C.gpgme_key_t)(recp), C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return err
+	}
+	c.pending = []interface{}{recipients, plaintext, ciphertext}
+	return nil
+}
+
+// EncryptSignStart starts an asynchronous EncryptSign. Use Wait to complete it
+// and EncryptResult and SignResult to retrieve its results.
+func (c *Context) EncryptSignStart(recipients, signers []*Key, flags EncryptFlag, plaintext, ciphertext *Data) error {
+	if err := checkEncryptFlags(flags); err != nil {
+		return err
+	}
+	if err := c.SetSigners(signers); err != nil {
+		return err
+	}
+	recp := recipientArray(recipients)
+	defer C.free(recp)
+	err := handleError(C.gpgme_op_encrypt_sign_start(c.ctx, (*C.gpgme_key_t)(recp), C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return err
+	}
+	c.pending = []interface{}{recipients, plaintext, ciphertext}
+	return nil
+}
+
+// SignStart starts an asynchronous Sign. Use Wait to complete it and
+// SignResult to retrieve its result.
+func (c *Context) SignStart(signers []*Key, plain, sig *Data, mode SigMode) error {
+	if err := c.SetSigners(signers); err != nil {
+		return err
+	}
+	err := handleError(C.gpgme_op_sign_start(c.ctx, plain.dh, sig.dh, C.gpgme_sig_mode_t(mode)))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return err
+	}
+	c.pending = []interface{}{plain, sig}
+	return nil
+}
+
+// Wait blocks until the operation started by one of the Start methods has
+// finished and returns its error.
+func (c *Context) Wait() error {
+	var status C.gpgme_error_t
+	C.gpgme_wait(c.ctx, &status, 1)
+	runtime.KeepAlive(c)
+	c.pending = nil
+	return handleError(status)
+}
+
+// Cancel requests cancellation of the pending operation. Unlike other methods
+// it may be called from any goroutine; the operation then fails with an Error
+// with code ErrorCanceled.
+func (c *Context) Cancel() error {
+	err := handleError(C.gpgme_cancel_async(c.ctx))
+	runtime.KeepAlive(c)
+	return err
+}
+
+// waitContext is like Wait, but cancels the operation if ctx is done first and
+// returns the error of ctx in that case.
+func (c *Context) waitContext(ctx context.Context) error {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			_ = c.Cancel()
+		case <-done:
+		}
+	}()
+	err := c.Wait()
+	close(done)
+	wg.Wait()
+	return contextError(ctx, err)
+}
+
+// contextError returns ctx.Err() if err reports that the operation was
+// canceled because ctx is done, and err otherwise.
+func contextError(ctx context.Context, err error) error {
+	if e, ok := err.(Error); ok && e.Code() == ErrorCanceled && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// DecryptCtx is like Decrypt, but cancels the operation when ctx is done.
+func (c *Context) DecryptCtx(ctx context.Context, ciphertext, plaintext *Data) (*DecryptResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.DecryptStart(ciphertext, plaintext); err != nil {
+		return nil, err
+	}
+	err := c.waitContext(ctx)
+	return c.DecryptResult(), err
+}
+
+// DecryptVerifyCtx is like DecryptVerify, but cancels the operation when ctx
+// is done.
+func (c *Context) DecryptVerifyCtx(ctx context.Context, ciphertext, plaintext *Data) (*DecryptResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.DecryptVerifyStart(ciphertext, plaintext); err != nil {
+		return nil, err
+	}
+	err := c.waitContext(ctx)
+	return c.DecryptResult(), err
+}
+
+// VerifyCtx is like Verify, but cancels the operation when ctx is done.
+func (c *Context) VerifyCtx(ctx context.Context, sig, signedText, plain *Data) (string, []Signature, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	if err := c.VerifyStart(sig, signedText, plain); err != nil {
+		return "", nil, err
+	}
+	if err := c.waitContext(ctx); err != nil {
+		return "", nil, err
+	}
+	fileName, sigs := c.VerifyResult()
+	return fileName, sigs, nil
+}
+
+// EncryptCtx is like Encrypt, but cancels the operation when ctx is done.
+func (c *Context) EncryptCtx(ctx context.Context, recipients []*Key, flags EncryptFlag, plaintext, ciphertext *Data) (*EncryptResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.EncryptStart(recipients, flags, plaintext, ciphertext); err != nil {
+		return nil, err
+	}
+	err := c.waitContext(ctx)
+	return c.EncryptResult(), err
+}
+
+// EncryptSignCtx is like EncryptSign, but cancels the operation when ctx is
+// done.
+func (c *Context) EncryptSignCtx(ctx context.Context, recipients, signers []*Key, flags EncryptFlag, plaintext, ciphertext *Data) (*EncryptResult, *SignResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	if err := c.EncryptSignStart(recipients, signers, flags, plaintext, ciphertext); err != nil {
+		return nil, nil, err
+	}
+	err := c.waitContext(ctx)
+	return c.EncryptResult(), c.SignResult(), err
+}
+
+// SignCtx is like Sign, but cancels the operation when ctx is done.
+func (c *Context) SignCtx(ctx context.Context, signers []*Key, plain, sig *Data, mode SigMode) (*SignResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.SignStart(signers, plain, sig, mode); err != nil {
+		return nil, err
+	}
+	err := c.waitContext(ctx)
+	return c.SignResult(), err
+}
+
+type AssuanDataCallback func(data []byte) error
 type AssuanInquireCallback func(name, args string) error
 type AssuanStatusCallback func(status, args string) error
 
@@ -729,6 +1673,380 @@ func (c *Context) Import(keyData *Data) (*ImportResult, error) {
 	return importResult, nil
 }
 
+// CreateKeyFlags controls the key generated by CreateKey and CreateSubKey
+type CreateKeyFlags uint
+
+const (
+	CreateSign         CreateKeyFlags = C.GPGME_CREATE_SIGN
+	CreateEncrypt      CreateKeyFlags = C.GPGME_CREATE_ENCR
+	CreateCertify      CreateKeyFlags = C.GPGME_CREATE_CERT
+	CreateAuthenticate CreateKeyFlags = C.GPGME_CREATE_AUTH
+	CreateNoPassword   CreateKeyFlags = C.GPGME_CREATE_NOPASSWD
+	CreateSelfSigned   CreateKeyFlags = C.GPGME_CREATE_SELFSIGNED
+	CreateNoStore      CreateKeyFlags = C.GPGME_CREATE_NOSTORE
+	CreateForce        CreateKeyFlags = C.GPGME_CREATE_FORCE
+	CreateNoExpire     CreateKeyFlags = C.GPGME_CREATE_NOEXPIRE
+)
+
+// GenKeyResult describes the outcome of CreateKey and CreateSubKey.
+type GenKeyResult struct {
+	Fingerprint string
+	Primary     bool
+	Sub         bool
+	UID         bool
+}
+
+// expiresIn converts an absolute expiration time into the number of seconds
+// from now expected by GPGME. The zero time selects the engine default.
+func expiresIn(expires time.Time) (C.ulong, error) {
+	if expires.IsZero() {
+		return 0, nil
+	}
+	d := time.Until(expires)
+	if d < time.Second {
+		return 0, fmt.Errorf("expiration time %v is in the past", expires)
+	}
+	return C.ulong(d / time.Second), nil
+}
+
+// CreateKey generates a new primary key for userID. An empty algo selects the
+// engine default; the zero expires uses the default expiration period.
+func (c *Context) CreateKey(userID, algo string, expires time.Time, flags CreateKeyFlags) (*GenKeyResult, error) {
+	exp, err := expiresIn(expires)
+	if err != nil {
+		return nil, err
+	}
+	cuid := C.CString(userID)
+	defer C.free(unsafe.Pointer(cuid))
+	var calgo *C.char
+	if algo != "" {
+		calgo = C.CString(algo)
+		defer C.free(unsafe.Pointer(calgo))
+	}
+	err = handleError(C.gpgme_op_createkey(c.ctx, cuid, calgo, 0, exp, nil, C.uint(flags)))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return nil, err
+	}
+	return c.genKeyResult(), nil
+}
+
+// CreateSubKey adds a new subkey to key, which must have a secret part.
+func (c *Context) CreateSubKey(key *Key, algo string, expires time.Time, flags CreateKeyFlags) (*GenKeyResult, error) {
+	exp, err := expiresIn(expires)
+	if err != nil {
+		return nil, err
+	}
+	var calgo *C.char
+	if algo != "" {
+		calgo = C.CString(algo)
+		defer C.free(unsafe.Pointer(calgo))
+	}
+	err = handleError(C.gpgme_op_createsubkey(c.ctx, key.k, calgo, 0, exp, C.uint(flags)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	if err != nil {
+		return nil, err
+	}
+	return c.genKeyResult(), nil
+}
+
+// AddUID adds userID to key, which must have a secret part.
+func (c *Context) AddUID(key *Key, userID string) error {
+	cuid := C.CString(userID)
+	defer C.free(unsafe.Pointer(cuid))
+	err := handleError(C.gpgme_op_adduid(c.ctx, key.k, cuid, 0))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// RevokeUID revokes userID on key, which must have a secret part.
+func (c *Context) RevokeUID(key *Key, userID string) error {
+	cuid := C.CString(userID)
+	defer C.free(unsafe.Pointer(cuid))
+	err := handleError(C.gpgme_op_revuid(c.ctx, key.k, cuid, 0))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// UIDFlagPrimary marks a user ID as the primary one when passed to SetUIDFlag.
+// It is currently the only flag known to GPGME and takes no value.
+const UIDFlagPrimary = "primary"
+
+// SetUIDFlag sets the flag name on userID of key. An empty value is passed to
+// GPGME as NULL.
+func (c *Context) SetUIDFlag(key *Key, userID, name, value string) error {
+	cuid := C.CString(userID)
+	defer C.free(unsafe.Pointer(cuid))
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	var cvalue *C.char
+	if value != "" {
+		cvalue = C.CString(value)
+		defer C.free(unsafe.Pointer(cvalue))
+	}
+	err := handleError(C.gpgme_op_set_uid_flag(c.ctx, key.k, cuid, cname, cvalue))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// SetExpire changes the expiration time of key, which must have a secret part.
+// The zero expires removes the expiration. A nil subkeyFprs changes the primary
+// key; otherwise the listed subkeys are changed instead, with []string{"*"}
+// selecting all of them.
+func (c *Context) SetExpire(key *Key, expires time.Time, subkeyFprs []string) error {
+	exp, err := expiresIn(expires)
+	if err != nil {
+		return err
+	}
+	var cfprs *C.char
+	if len(subkeyFprs) != 0 {
+		cfprs = C.CString(strings.Join(subkeyFprs, "\n"))
+		defer C.free(unsafe.Pointer(cfprs))
+	}
+	err = handleError(C.gpgme_op_setexpire(c.ctx, key.k, exp, cfprs, 0))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// KeySignFlags controls the certifications created by SignKey
+type KeySignFlags uint
+
+const (
+	KeySignLocal    KeySignFlags = C.GPGME_KEYSIGN_LOCAL
+	KeySignLFSep    KeySignFlags = C.GPGME_KEYSIGN_LFSEP
+	KeySignNoExpire KeySignFlags = C.GPGME_KEYSIGN_NOEXPIRE
+)
+
+// RevokeSignatureFlags controls RevokeSignature
+type RevokeSignatureFlags uint
+
+const (
+	RevokeSignatureLFSep RevokeSignatureFlags = C.GPGME_REVSIG_LFSEP
+)
+
+// joinUserIDs prepares a user ID list for GPGME. It returns nil for an empty
+// list, which selects all user IDs, and reports whether the list had to be
+// joined with line feeds.
+func joinUserIDs(userIDs []string) (*C.char, bool) {
+	switch len(userIDs) {
+	case 0:
+		return nil, false
+	case 1:
+		return C.CString(userIDs[0]), false
+	}
+	return C.CString(strings.Join(userIDs, "\n")), true
+}
+
+// SignKey certifies userIDs of key using the keys set with SetSigners. An empty
+// userIDs certifies all user IDs of key.
+func (c *Context) SignKey(key *Key, userIDs []string, expires time.Time, flags KeySignFlags) error {
+	exp, err := expiresIn(expires)
+	if err != nil {
+		return err
+	}
+	cuids, lfsep := joinUserIDs(userIDs)
+	if cuids != nil {
+		defer C.free(unsafe.Pointer(cuids))
+	}
+	if lfsep {
+		flags |= KeySignLFSep
+	}
+	err = handleError(C.gpgme_op_keysign(c.ctx, key.k, cuids, exp, C.uint(flags)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// RevokeSignature revokes the certifications made by signingKey on userIDs of
+// key. An empty userIDs revokes the certifications on all user IDs.
+func (c *Context) RevokeSignature(key, signingKey *Key, userIDs []string, flags RevokeSignatureFlags) error {
+	cuids, lfsep := joinUserIDs(userIDs)
+	if cuids != nil {
+		defer C.free(unsafe.Pointer(cuids))
+	}
+	if lfsep {
+		flags |= RevokeSignatureLFSep
+	}
+	err := handleError(C.gpgme_op_revsig(c.ctx, key.k, signingKey.k, cuids, C.uint(flags)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(signingKey)
+	return err
+}
+
+func (c *Context) genKeyResult() *GenKeyResult {
+	res := C.gpgme_op_genkey_result(c.ctx)
+	runtime.KeepAlive(c)
+	// NOTE: c must be live as long as we are accessing res.
+	genKeyResult := &GenKeyResult{
+		Fingerprint: C.GoString(res.fpr),
+		Primary:     C.genkey_result_primary(res) != 0,
+		Sub:         C.genkey_result_sub(res) != 0,
+		UID:         C.genkey_result_uid(res) != 0,
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return genKeyResult
+}
+
+// DeleteFlags controls Delete
+type DeleteFlags uint
+
+const (
+	DeleteAllowSecret DeleteFlags = C.GPGME_DELETE_ALLOW_SECRET
+	DeleteForce       DeleteFlags = C.GPGME_DELETE_FORCE
+)
+
+// Delete removes key from the keyring. On failure the returned Error has code
+// ErrorNoPublicKey if the key was not found, ErrorConflict if it has a secret
+// part and DeleteAllowSecret was not given, or ErrorAmbiguousName if the key
+// could not be identified uniquely.
+func (c *Context) Delete(key *Key, flags DeleteFlags) error {
+	err := handleError(C.gpgme_op_delete_ext(c.ctx, key.k, C.uint(flags)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// SetTofuPolicy sets the TOFU policy for all bindings of key.
+func (c *Context) SetTofuPolicy(key *Key, policy TofuPolicy) error {
+	err := handleError(C.gpgme_op_tofu_policy(c.ctx, key.k, C.gpgme_tofu_policy_t(policy)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// ownerTrustValues maps a Validity to the value understood by
+// gpgme_op_setownertrust and the answer to gpg's edit_ownertrust.value prompt.
+var ownerTrustValues = map[Validity]struct{ value, answer string }{
+	ValidityUnknown:   {"undefined", "1"},
+	ValidityUndefined: {"undefined", "1"},
+	ValidityNever:     {"never", "2"},
+	ValidityMarginal:  {"marginal", "3"},
+	ValidityFull:      {"full", "4"},
+	ValidityUltimate:  {"ultimate", "5"},
+}
+
+// SetOwnerTrust sets the owner trust of key. With GPGME older than 1.24, or
+// GnuPG older than 2.4.6, the trust is set by driving gpg's --edit-key.
+func (c *Context) SetOwnerTrust(key *Key, trust Validity) error {
+	v, ok := ownerTrustValues[trust]
+	if !ok {
+		return fmt.Errorf("invalid owner trust %d", trust)
+	}
+	cvalue := C.CString(v.value)
+	defer C.free(unsafe.Pointer(cvalue))
+	err := handleError(C.gogpgme_op_setownertrust(c.ctx, key.k, cvalue))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	if e, ok := err.(Error); ok && (e.Code() == ErrorCode(C.GPG_ERR_NOT_IMPLEMENTED) || e.Code() == ErrorNotSupported) {
+		return c.Interact(key, 0, ownerTrustEditor(v.answer), nil)
+	}
+	return err
+}
+
+// ownerTrustEditor answers the --edit-key prompts for setting the owner trust.
+func ownerTrustEditor(answer string) InteractCallback {
+	done := false
+	return func(status, args string, w io.Writer) error {
+		var response string
+		switch {
+		case status == "GET_LINE" && args == "keyedit.prompt":
+			response = "trust"
+			if done {
+				response = "quit"
+			}
+			done = true
+		case status == "GET_LINE" && args == "edit_ownertrust.value":
+			response = answer
+		case status == "GET_BOOL" && (args == "edit_ownertrust.set_ultimate.okay" || args == "keyedit.save.okay"):
+			response = "Y"
+		case w != nil:
+			return fmt.Errorf("unexpected prompt %s %s", status, args)
+		default:
+			return nil
+		}
+		_, err := io.WriteString(w, response+"\n")
+		return err
+	}
+}
+
+// InteractFlags controls Interact
+type InteractFlags uint
+
+const (
+	InteractCard InteractFlags = C.GPGME_INTERACT_CARD
+)
+
+// InteractCallback is called for every status line emitted while editing a
+// key. w is nil unless the engine expects a response, i.e. for GET_BOOL,
+// GET_LINE and GET_HIDDEN; the answer must be terminated by a line feed.
+// Returning an error aborts the interaction.
+type InteractCallback func(status, args string, w io.Writer) error
+
+type interactHandler struct {
+	cb  InteractCallback
+	err error // the error returned by cb, if any
+}
+
+// interactWriter writes responses to the command file descriptor of an
+// interaction. The descriptor is owned by GPGME and must not be closed.
+type interactWriter C.int
+
+func (w interactWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if res, err := C.gpgme_io_writen(C.int(w), unsafe.Pointer(&p[0]), C.size_t(len(p))); res != 0 {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+//export gogpgme_interactfunc
+func gogpgme_interactfunc(handle unsafe.Pointer, status, args *C.char, fd C.int) C.gpgme_error_t {
+	h := callbackLookup(uintptr(handle)).(*interactHandler)
+	var w io.Writer
+	if fd >= 0 {
+		w = interactWriter(fd)
+	}
+	if err := h.cb(C.GoString(status), C.GoString(args), w); err != nil {
+		h.err = err
+		return C.gpgme_error(C.GPG_ERR_USER_1)
+	}
+	return 0
+}
+
+// Interact runs gpg's --edit-key (or --card-edit with InteractCard) on key,
+// calling cb to answer its prompts. The normal output of the engine is written
+// to out, which may be nil. key may be nil with InteractCard. If cb returns an
+// error, Interact returns it.
+func (c *Context) Interact(key *Key, flags InteractFlags, cb InteractCallback, out *Data) error {
+	h := &interactHandler{cb: cb}
+	handle := callbackAdd(h)
+	defer callbackDelete(handle)
+	var ck C.gpgme_key_t
+	if key != nil {
+		ck = key.k
+	}
+	var dh C.gpgme_data_t
+	if out != nil {
+		dh = out.dh
+	}
+	err := handleError(C.gogpgme_op_interact(c.ctx, ck, C.uint(flags), C.uintptr_t(handle), dh))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(out)
+	if h.err != nil {
+		return h.err
+	}
+	return err
+}
+
 type Key struct {
 	k C.gpgme_key_t // WARNING: Call Runtime.KeepAlive(k) after ANY passing of k.k to C
 }
@@ -835,6 +2153,73 @@ func (k *Key) OwnerTrust() Validity {
 	return res
 }
 
+// Fingerprint returns the fingerprint of the primary key.
+func (k *Key) Fingerprint() string {
+	res := C.GoString(k.k.fpr)
+	runtime.KeepAlive(k)
+	return res
+}
+
+func (k *Key) Origin() KeyOrigin {
+	res := KeyOrigin(C.key_origin(k.k))
+	runtime.KeepAlive(k)
+	return res
+}
+
+// LastUpdate returns the time the key was last updated from its origin, or the
+// zero time if unknown.
+func (k *Key) LastUpdate() time.Time {
+	t := k.k.last_update
+	runtime.KeepAlive(k)
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(t), 0)
+}
+
+// HasEncrypt reports whether the key has a usable encryption subkey.
+func (k *Key) HasEncrypt() bool {
+	res := C.key_has_encrypt(k.k) != 0
+	runtime.KeepAlive(k)
+	return res
+}
+
+// HasSign reports whether the key has a usable signing subkey.
+func (k *Key) HasSign() bool {
+	res := C.key_has_sign(k.k) != 0
+	runtime.KeepAlive(k)
+	return res
+}
+
+// HasCertify reports whether the key has a usable certification subkey.
+func (k *Key) HasCertify() bool {
+	res := C.key_has_certify(k.k) != 0
+	runtime.KeepAlive(k)
+	return res
+}
+
+// HasAuthenticate reports whether the key has a usable authentication subkey.
+func (k *Key) HasAuthenticate() bool {
+	res := C.key_has_authenticate(k.k) != 0
+	runtime.KeepAlive(k)
+	return res
+}
+
+// IsDeVs reports whether all subkeys of the key are compliant with the de-vs
+// mode.
+func (k *Key) IsDeVs() bool {
+	sk := k.SubKeys()
+	if sk == nil {
+		return false
+	}
+	for ; sk != nil; sk = sk.Next() {
+		if !sk.IsDeVs() {
+			return false
+		}
+	}
+	return true
+}
+
 func (k *Key) SubKeys() *SubKey {
 	subKeys := k.k.subkeys
 	runtime.KeepAlive(k)
@@ -891,6 +2276,64 @@ func (k *SubKey) Secret() bool {
 	return C.subkey_secret(k.k) != 0
 }
 
+func (k *SubKey) CanEncrypt() bool {
+	return C.subkey_can_encrypt(k.k) != 0
+}
+
+func (k *SubKey) CanSign() bool {
+	return C.subkey_can_sign(k.k) != 0
+}
+
+func (k *SubKey) CanCertify() bool {
+	return C.subkey_can_certify(k.k) != 0
+}
+
+func (k *SubKey) CanAuthenticate() bool {
+	return C.subkey_can_authenticate(k.k) != 0
+}
+
+// CanRenc reports whether the subkey can be used to re-encrypt (GPGME 1.23 or
+// later; always false otherwise).
+func (k *SubKey) CanRenc() bool {
+	return C.subkey_can_renc(k.k) != 0
+}
+
+// CanTimestamp reports whether the subkey can be used for timestamping
+// (GPGME 1.23 or later; always false otherwise).
+func (k *SubKey) CanTimestamp() bool {
+	return C.subkey_can_timestamp(k.k) != 0
+}
+
+func (k *SubKey) IsQualified() bool {
+	return C.subkey_is_qualified(k.k) != 0
+}
+
+// IsCardKey reports whether the secret key is stored on a smart card.
+func (k *SubKey) IsCardKey() bool {
+	return C.subkey_is_cardkey(k.k) != 0
+}
+
+// IsDeVs reports whether the subkey is compliant with the German VS-NfD
+// (de-vs) mode.
+func (k *SubKey) IsDeVs() bool {
+	return C.subkey_is_de_vs(k.k) != 0
+}
+
+// IsGroupOwned reports whether the secret key is shared by a group (GPGME 1.23
+// or later; always false otherwise).
+func (k *SubKey) IsGroupOwned() bool {
+	return C.subkey_is_group_owned(k.k) != 0
+}
+
+func (k *SubKey) PubkeyAlgo() PubkeyAlgo {
+	return PubkeyAlgo(k.k.pubkey_algo)
+}
+
+// Length returns the key size in bits.
+func (k *SubKey) Length() uint {
+	return uint(k.k.length)
+}
+
 func (k *SubKey) KeyID() string {
 	return C.GoString(k.k.keyid)
 }
@@ -917,6 +2360,26 @@ func (k *SubKey) CardNumber() string {
 	return C.GoString(k.k.card_number)
 }
 
+// Curve returns the name of the elliptic curve for ECC keys, or "".
+func (k *SubKey) Curve() string {
+	return C.GoString(k.k.curve)
+}
+
+func (k *SubKey) Keygrip() string {
+	return C.GoString(k.k.keygrip)
+}
+
+// AlgoString returns the algorithm and size of the subkey in the format used
+// by GnuPG, e.g. "rsa3072" or "ed25519".
+func (k *SubKey) AlgoString() string {
+	res := C.gpgme_pubkey_algo_string(k.k)
+	if res == nil {
+		return ""
+	}
+	defer C.gpgme_free(unsafe.Pointer(res))
+	return C.GoString(res)
+}
+
 type UserID struct {
 	u      C.gpgme_user_id_t
 	parent *Key // make sure the key is not released when we have a reference to a user ID
@@ -956,3 +2419,175 @@ func (u *UserID) Comment() string {
 func (u *UserID) Email() string {
 	return C.GoString(u.u.email)
 }
+
+// Address returns the normalized mail address (addr-spec) of the user ID, or
+// "" if it has none.
+func (u *UserID) Address() string {
+	return C.GoString(u.u.address)
+}
+
+func (u *UserID) Origin() KeyOrigin {
+	return KeyOrigin(C.uid_origin(u.u))
+}
+
+// LastUpdate returns the time the user ID was last updated from its origin,
+// or the zero time if unknown.
+func (u *UserID) LastUpdate() time.Time {
+	if u.u.last_update == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(u.u.last_update), 0)
+}
+
+// UIDHash returns the hash GnuPG uses to identify the user ID.
+func (u *UserID) UIDHash() string {
+	return C.GoString(u.u.uidhash)
+}
+
+// TOFU returns the TOFU statistics for the user ID, or nil. They are only
+// available if the key was listed with KeyListModeWithTOFU and the engine uses
+// a TOFU trust model (see SetTrustModel).
+func (u *UserID) TOFU() *TofuInfo {
+	t := u.u.tofu
+	if t == nil {
+		return nil
+	}
+	return &TofuInfo{
+		Validity:    TofuValidity(C.tofu_info_validity(t)),
+		Policy:      TofuPolicy(C.tofu_info_policy(t)),
+		SignCount:   uint(t.signcount),
+		EncrCount:   uint(t.encrcount),
+		SignFirst:   tofuTime(t.signfirst),
+		SignLast:    tofuTime(t.signlast),
+		EncrFirst:   tofuTime(t.encrfirst),
+		EncrLast:    tofuTime(t.encrlast),
+		Description: C.GoString(t.description),
+	}
+}
+
+// TofuInfo holds the TOFU statistics of a user ID.
+type TofuInfo struct {
+	Validity    TofuValidity
+	Policy      TofuPolicy
+	SignCount   uint // number of signatures seen
+	EncrCount   uint // number of encryptions done
+	SignFirst   time.Time
+	SignLast    time.Time
+	EncrFirst   time.Time
+	EncrLast    time.Time
+	Description string
+}
+
+func tofuTime(t C.ulong) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(t), 0)
+}
+
+// Signatures returns the first certification on the user ID, or nil. They are
+// only available if the key was listed with KeyListModeSigs.
+func (u *UserID) Signatures() *KeySig {
+	if u.u.signatures == nil {
+		return nil
+	}
+	return &KeySig{s: u.u.signatures, parent: u.parent}
+}
+
+// KeySig is a signature on a user ID (a certification).
+type KeySig struct {
+	s      C.gpgme_key_sig_t
+	parent *Key // make sure the key is not released when we have a reference to a key signature
+}
+
+func (s *KeySig) Next() *KeySig {
+	if s.s.next == nil {
+		return nil
+	}
+	return &KeySig{s: s.s.next, parent: s.parent}
+}
+
+func (s *KeySig) Revoked() bool {
+	return C.key_sig_revoked(s.s) != 0
+}
+
+func (s *KeySig) Expired() bool {
+	return C.key_sig_expired(s.s) != 0
+}
+
+func (s *KeySig) Invalid() bool {
+	return C.key_sig_invalid(s.s) != 0
+}
+
+func (s *KeySig) Exportable() bool {
+	return C.key_sig_exportable(s.s) != 0
+}
+
+func (s *KeySig) PubkeyAlgo() PubkeyAlgo {
+	return PubkeyAlgo(s.s.pubkey_algo)
+}
+
+// KeyID returns the ID of the key that made the signature.
+func (s *KeySig) KeyID() string {
+	return C.GoString(s.s.keyid)
+}
+
+func (s *KeySig) Created() time.Time {
+	if s.s.timestamp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(s.s.timestamp), 0)
+}
+
+func (s *KeySig) Expires() time.Time {
+	if s.s.expires <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(s.s.expires), 0)
+}
+
+// Status returns the result of checking the signature, or nil if it is valid.
+func (s *KeySig) Status() error {
+	return handleError(s.s.status)
+}
+
+func (s *KeySig) SigClass() uint {
+	return uint(s.s.sig_class)
+}
+
+// UID returns the main user ID of the signing key, if it is known.
+func (s *KeySig) UID() string {
+	return C.GoString(s.s.uid)
+}
+
+func (s *KeySig) Name() string {
+	return C.GoString(s.s.name)
+}
+
+func (s *KeySig) Email() string {
+	return C.GoString(s.s.email)
+}
+
+func (s *KeySig) Comment() string {
+	return C.GoString(s.s.comment)
+}
+
+// TrustDepth, TrustValue and TrustScope describe a trust signature; they are
+// zero for ordinary certifications.
+func (s *KeySig) TrustDepth() uint {
+	return uint(C.key_sig_trust_depth(s.s))
+}
+
+func (s *KeySig) TrustValue() uint {
+	return uint(C.key_sig_trust_value(s.s))
+}
+
+func (s *KeySig) TrustScope() string {
+	return C.GoString(s.s.trust_scope)
+}
+
+// Notations returns the notation data of the signature. They are only
+// available if the key was listed with KeyListModeSigNotations.
+func (s *KeySig) Notations() []SigNotation {
+	return copySigNotations(s.s.notations)
+}
@@ -2,14 +2,19 @@ package gpgme
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 const (
@@ -59,6 +64,15 @@ jlfmWMcAkXASNXl9/Yso2XGJMs85JPhZPJ3KJRuuurnhZSxAbDJMNBFJ+HbQv3y6
 pupeR7ut6pWJxr6MND793yoFGoRYwKklQdfP4xzFCatYRU4RkPBp95KJ
 =RMUj
 -----END PGP MESSAGE-----
+`
+	// "Test message\n" encrypted with CAST5 and the passphrase "password",
+	// without integrity protection.
+	testNoMDCCipherText = `-----BEGIN PGP MESSAGE-----
+
+jA0EAwMCLLsjH23xk1j/pCQ+J9eB9METivIlYAwfS4Be4TExmzWpKEtz+r6JAlN7
+AYz8I/o=
+=lN6r
+-----END PGP MESSAGE-----
 `
 )
 
@@ -121,6 +135,128 @@ func ctxWithCallback(t *testing.T) *Context {
 	return ctx
 }
 
+// ctxWithTempHome returns a context using a scratch GnuPG home directory, seeded
+// with a copy of the test keyring when seed is set. Key management operations
+// require GnuPG 2.1 or later, which also needs loopback pinentry for the passphrase.
+func ctxWithTempHome(t *testing.T, seed bool) (*Context, string) {
+	t.Helper()
+	if isVersion(t, "1.") {
+		t.Skip("key management requires GnuPG 2.1 or later")
+	}
+	homeDir, err := ioutil.TempDir("", "gpgme-test")
+	checkError(t, err)
+	if seed {
+		files, err := ioutil.ReadDir(testGPGHome)
+		checkError(t, err)
+		for _, f := range files {
+			b, err := ioutil.ReadFile(filepath.Join(testGPGHome, f.Name()))
+			checkError(t, err)
+			checkError(t, ioutil.WriteFile(filepath.Join(homeDir, f.Name()), b, 0600))
+		}
+	}
+
+	ctx, err := New()
+	checkError(t, err)
+	checkError(t, ctx.SetEngineInfo(ProtocolOpenPGP, "", homeDir))
+	checkError(t, ctx.SetPinEntryMode(PinEntryLoopback))
+	checkError(t, ctx.SetCallback(func(uid_hint string, prev_was_bad bool, f *os.File) error {
+		if prev_was_bad {
+			t.Fatal("Bad passphrase")
+		}
+		_, err := io.WriteString(f, "password\n")
+		return err
+	}))
+	return ctx, homeDir
+}
+
+func TestAlgoString(t *testing.T) {
+	kyber := "PubkeyAlgo(8)" // unknown to GPGME before 1.24
+	if versionAtLeast("1.24.0") {
+		kyber = "Kyber"
+	}
+	for _, v := range []struct {
+		value    fmt.Stringer
+		expected string
+	}{
+		{HashAlgoSHA1, "SHA1"},
+		{HashAlgoSHA256, "SHA256"},
+		{HashAlgo(-1), "HashAlgo(-1)"},
+		{PubkeyAlgoRSA, "RSA"},
+		{PubkeyAlgoEdDSA, "EdDSA"},
+		{PubkeyAlgoKyber, kyber},
+		{PubkeyAlgo(-1), "PubkeyAlgo(-1)"},
+	} {
+		if s := v.value.String(); s != v.expected {
+			t.Errorf("Unexpected algorithm name %q, expected %q", s, v.expected)
+		}
+	}
+
+	key, err := FindKeys("test@example.com", false)
+	checkError(t, err)
+	if len(key) != 1 {
+		t.Fatalf("Expected 1 key, got %d", len(key))
+	}
+	if s := key[0].SubKeys().AlgoString(); s != "rsa2048" {
+		t.Errorf("Unexpected subkey algorithm %q", s)
+	}
+}
+
+func TestSubKey(t *testing.T) {
+	key, err := FindKeys("test@example.com", false)
+	checkError(t, err)
+	if len(key) != 1 {
+		t.Fatalf("Expected 1 key, got %d", len(key))
+	}
+	primary := key[0].SubKeys()
+	if primary.PubkeyAlgo() != PubkeyAlgoRSA || primary.Length() != 2048 {
+		t.Errorf("Unexpected primary key algorithm %v/%d", primary.PubkeyAlgo(), primary.Length())
+	}
+	if !primary.CanSign() || !primary.CanCertify() || primary.CanEncrypt() || primary.CanAuthenticate() {
+		t.Error("Unexpected primary key capabilities")
+	}
+	if primary.Curve() != "" {
+		t.Errorf("Unexpected curve %q for an RSA key", primary.Curve())
+	}
+	sub := primary.Next()
+	if sub == nil {
+		t.Fatal("Expected an encryption subkey")
+	}
+	if !sub.CanEncrypt() || sub.CanSign() || sub.CanCertify() || sub.IsCardKey() {
+		t.Error("Unexpected subkey capabilities")
+	}
+	if isVersion(t, "1.") {
+		return // GnuPG 1.x does not report keygrips
+	}
+	if primary.Keygrip() != "A0923BB4B1E8C4F31D8DE99C8A90718083269474" {
+		t.Errorf("Unexpected keygrip %q", primary.Keygrip())
+	}
+	if sub.Keygrip() != "13E6663DF044B6A8DEA26DB490E2B72F75D7BB7B" {
+		t.Errorf("Unexpected keygrip %q", sub.Keygrip())
+	}
+}
+
+func TestKeyMetadata(t *testing.T) {
+	key, err := FindKeys("test@example.com", false)
+	checkError(t, err)
+	if len(key) != 1 {
+		t.Fatalf("Expected 1 key, got %d", len(key))
+	}
+	k := key[0]
+	if k.Fingerprint() != "44B646DC347C31E867FF4F450327FFB0229F6136" {
+		t.Errorf("Unexpected fingerprint %q", k.Fingerprint())
+	}
+	if !k.HasEncrypt() || !k.HasSign() || !k.HasCertify() || k.HasAuthenticate() {
+		t.Error("Unexpected key capabilities")
+	}
+	uid := k.UserIDs()
+	if uid.Address() != "test@example.com" {
+		t.Errorf("Unexpected address %q", uid.Address())
+	}
+	if uid.TOFU() != nil {
+		t.Error("Expected no TOFU information in the default key listing")
+	}
+}
+
 func TestContext_Armor(t *testing.T) {
 	ctx, err := New()
 	checkError(t, err)
@@ -149,6 +285,276 @@ func TestContext_TextMode(t *testing.T) {
 	}
 }
 
+func TestContext_Offline(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	ctx.SetOffline(true)
+	if !ctx.Offline() {
+		t.Error("expected offline set")
+	}
+	ctx.SetOffline(false)
+	if ctx.Offline() {
+		t.Error("expected offline not set")
+	}
+}
+
+func TestContext_Sender(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	checkError(t, ctx.SetSender("Test Key <test@example.com>"))
+	if sender := ctx.Sender(); sender != "test@example.com" {
+		t.Errorf("Unexpected sender %q", sender)
+	}
+	checkError(t, ctx.SetSender(""))
+	if sender := ctx.Sender(); sender != "" {
+		t.Errorf("Expected sender cleared, got %q", sender)
+	}
+}
+
+func TestContext_IncludeCerts(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	ctx.SetIncludeCerts(2)
+	if n := ctx.IncludeCerts(); n != 2 {
+		t.Errorf("Unexpected include certs %d", n)
+	}
+	ctx.SetIncludeCerts(IncludeCertsDefault)
+	if n := ctx.IncludeCerts(); n != IncludeCertsDefault {
+		t.Errorf("Unexpected include certs %d", n)
+	}
+}
+
+func TestContext_Flag(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	checkError(t, ctx.SetFlag("full-status", "1"))
+	if v := ctx.Flag("full-status"); v != "1" {
+		t.Errorf("Expected flag full-status set, got %q", v)
+	}
+	if v := ctx.Flag("no-such-flag"); v != "" {
+		t.Errorf("Expected unknown flag to be empty, got %q", v)
+	}
+
+	for _, f := range []struct {
+		name string
+		set  func(bool) error
+		get  func() bool
+	}{
+		{"auto-key-retrieve", ctx.SetAutoKeyRetrieve, ctx.AutoKeyRetrieve},
+		{"no-symkey-cache", ctx.SetNoSymKeyCache, ctx.NoSymKeyCache},
+		{"include-key-block", ctx.SetIncludeKeyBlock, ctx.IncludeKeyBlock},
+		{"ignore-mdc-error", ctx.SetIgnoreMDCError, ctx.IgnoreMDCError},
+		{"export-session-key", ctx.SetExportSessionKey, ctx.ExportSessionKey},
+	} {
+		checkError(t, f.set(true))
+		if !f.get() {
+			t.Errorf("Expected flag %s set", f.name)
+		}
+		checkError(t, f.set(false))
+		if f.get() {
+			t.Errorf("Expected flag %s not set", f.name)
+		}
+	}
+
+	// no-symkey-cache, request-origin and ignore-mdc-error are exercised by
+	// their own tests below. auto-key-retrieve only has an effect when keys
+	// can be fetched from the network, so it is only checked here.
+
+	checkError(t, ctx.SetTrustModel("always"))
+	if v := ctx.TrustModel(); v != "always" {
+		t.Errorf("Unexpected trust model %q", v)
+	}
+	checkError(t, ctx.SetRequestOrigin("remote"))
+	if v := ctx.RequestOrigin(); v != "remote" {
+		t.Errorf("Unexpected request origin %q", v)
+	}
+	checkError(t, ctx.SetOverrideSessionKey("9:0123"))
+	if v := ctx.OverrideSessionKey(); v != "9:0123" {
+		t.Errorf("Unexpected override session key %q", v)
+	}
+}
+
+// symmetricTestContext returns a context in a fresh home directory which counts
+// the passphrase requests in *calls, and testData encrypted symmetrically in it.
+func symmetricTestContext(t *testing.T, calls *int) (*Context, string, []byte) {
+	t.Helper()
+	ctx, homeDir := ctxWithTempHome(t, false)
+	checkError(t, ctx.SetCallback(func(uid_hint string, prev_was_bad bool, f *os.File) error {
+		*calls++
+		_, err := io.WriteString(f, "password\n")
+		return err
+	}))
+	checkError(t, ctx.SetNoSymKeyCache(true))
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	var buf bytes.Buffer
+	cipher, err := NewDataWriter(&buf)
+	checkError(t, err)
+	checkError(t, ctx.EncryptSymmetric(plain, cipher))
+	return ctx, homeDir, buf.Bytes()
+}
+
+func decryptBytes(ctx *Context, ciphertext []byte) ([]byte, error) {
+	cipher, err := NewDataBytes(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	plain, err := NewDataWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	_, err = ctx.Decrypt(cipher, plain)
+	return buf.Bytes(), err
+}
+
+func TestContext_NoSymKeyCache(t *testing.T) {
+	var calls int
+	ctx, homeDir, ciphertext := symmetricTestContext(t, &calls)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	for i := 0; i < 2; i++ {
+		n := calls
+		_, err := decryptBytes(ctx, ciphertext)
+		checkError(t, err)
+		if calls == n {
+			t.Errorf("Expected passphrase request for decryption %d with no-symkey-cache", i)
+		}
+	}
+
+	checkError(t, ctx.SetNoSymKeyCache(false))
+	_, err := decryptBytes(ctx, ciphertext)
+	checkError(t, err)
+	n := calls
+	_, err = decryptBytes(ctx, ciphertext)
+	checkError(t, err)
+	if calls != n {
+		t.Error("Expected the passphrase to be cached without no-symkey-cache")
+	}
+}
+
+func TestContext_RequestOrigin(t *testing.T) {
+	var calls int
+	ctx, homeDir, ciphertext := symmetricTestContext(t, &calls)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	// gpg-agent does not allow remote requests to use the loopback pinentry.
+	checkError(t, ctx.SetRequestOrigin("remote"))
+	if _, err := decryptBytes(ctx, ciphertext); err == nil {
+		t.Error("Expected decryption to fail for a remote request")
+	}
+	checkError(t, ctx.SetRequestOrigin("local"))
+	plain, err := decryptBytes(ctx, ciphertext)
+	checkError(t, err)
+	diff(t, plain, []byte(testData))
+}
+
+func TestContext_IgnoreMDCError(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, false)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+	checkError(t, ctx.SetNoSymKeyCache(true))
+
+	if _, err := decryptBytes(ctx, []byte(testNoMDCCipherText)); err == nil {
+		t.Error("Expected decryption without integrity protection to fail")
+	}
+	checkError(t, ctx.SetIgnoreMDCError(true))
+	plain, err := decryptBytes(ctx, []byte(testNoMDCCipherText))
+	checkError(t, err)
+	diff(t, plain, []byte("Test message\n"))
+}
+
+func TestContext_SessionKey(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	checkError(t, ctx.SetExportSessionKey(true))
+	cipher, err := NewDataBytes([]byte(testCipherText))
+	checkError(t, err)
+	plain, err := NewData()
+	checkError(t, err)
+	res, err := ctx.Decrypt(cipher, plain)
+	checkError(t, err)
+	if res.SessionKey == "" {
+		t.Fatal("Expected an exported session key")
+	}
+
+	// A keyring without the secret key can decrypt given the session key.
+	other, otherHomeDir := ctxWithTempHome(t, false)
+	defer os.RemoveAll(otherHomeDir)
+	defer other.Release()
+
+	checkError(t, other.SetOverrideSessionKey(res.SessionKey))
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	var buf bytes.Buffer
+	plain, err = NewDataWriter(&buf)
+	checkError(t, err)
+	_, err = other.Decrypt(cipher, plain)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte("Test message\n"))
+}
+
+func TestContext_TrustModel(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, false)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	keyData, err := NewDataBytes(mustReadFile(t, "./testdata/pubkeys.gpg"))
+	checkError(t, err)
+	_, err = ctx.Import(keyData)
+	checkError(t, err)
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+
+	encrypt := func() error {
+		plain, err := NewDataBytes([]byte(testData))
+		checkError(t, err)
+		cipher, err := NewData()
+		checkError(t, err)
+		_, err = ctx.Encrypt([]*Key{key}, 0, plain, cipher)
+		return err
+	}
+	if err := encrypt(); err == nil {
+		t.Error("Expected encryption to an untrusted key to fail")
+	}
+	checkError(t, ctx.SetTrustModel("always"))
+	checkError(t, encrypt())
+}
+
+func TestContext_IncludeKeyBlock(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", true)
+	checkError(t, err)
+
+	sign := func() int {
+		plain, err := NewDataBytes([]byte(testData))
+		checkError(t, err)
+		var buf bytes.Buffer
+		sig, err := NewDataWriter(&buf)
+		checkError(t, err)
+		_, err = ctx.Sign([]*Key{key}, plain, sig, SigModeDetach)
+		checkError(t, err)
+		return buf.Len()
+	}
+	without := sign()
+	checkError(t, ctx.SetIncludeKeyBlock(true))
+	if with := sign(); with <= without {
+		t.Errorf("Expected signature with key block to be larger, got %d vs. %d bytes", with, without)
+	}
+}
+
 func TestContext_EngineInfo(t *testing.T) {
 	ctx, err := New()
 	checkError(t, err)
@@ -161,13 +567,430 @@ func TestContext_EngineInfo(t *testing.T) {
 	info := ctx.EngineInfo()
 	compareEngineInfo(t, info, testProto, testFN, testHomeDir)
 
-	// SetEngineInfo with empty strings works, using defaults which we don't know,
-	// so just test that it doesn't fail.
-	checkError(t, ctx.SetEngineInfo(testProto, testFN, ""))
-	checkError(t, ctx.SetEngineInfo(testProto, "", testHomeDir))
+	// SetEngineInfo with empty strings works, using defaults which we don't know,
+	// so just test that it doesn't fail.
+	checkError(t, ctx.SetEngineInfo(testProto, testFN, ""))
+	checkError(t, ctx.SetEngineInfo(testProto, "", testHomeDir))
+}
+
+func TestContext_Encrypt(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	keys, err := FindKeys("test@example.com", true)
+	checkError(t, err)
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+
+	var buf bytes.Buffer
+	cipher, err := NewDataWriter(&buf)
+	checkError(t, err)
+
+	res, err := ctx.Encrypt(keys, 0, plain, cipher)
+	checkError(t, err)
+	if buf.Len() < 1 {
+		t.Error("Expected encrypted bytes, got empty buffer")
+	}
+	if len(res.InvalidRecipients) != 0 {
+		t.Errorf("Unexpected invalid recipients %#v", res.InvalidRecipients)
+	}
+}
+
+func TestContext_SetProgressCallback(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	keys, err := FindKeys("test@example.com", true)
+	checkError(t, err)
+
+	var calls int
+	ctx.SetProgressCallback(func(what string, typ int, current, total int64) {
+		calls++
+		if total > 0 && current > total {
+			t.Errorf("Progress %d exceeds total %d", current, total)
+		}
+	})
+
+	plain, err := NewDataBytes(bytes.Repeat([]byte(testData), 1<<20))
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+
+	_, err = ctx.Encrypt(keys, EncryptAlwaysTrust, plain, cipher)
+	checkError(t, err)
+	if calls == 0 {
+		t.Error("Expected progress callback to be called")
+	}
+
+	ctx.SetProgressCallback(nil)
+	calls = 0
+	_, err = plain.Seek(0, SeekSet)
+	checkError(t, err)
+	_, err = ctx.Encrypt(keys, EncryptAlwaysTrust, plain, cipher)
+	checkError(t, err)
+	if calls != 0 {
+		t.Error("Expected no progress callback after it was removed")
+	}
+}
+
+func TestContext_SetStatusCallback(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	keys, err := FindKeys("test@example.com", true)
+	checkError(t, err)
+
+	keywords := map[string]bool{}
+	checkError(t, ctx.SetStatusCallback(func(keyword, args string) error {
+		keywords[keyword] = true
+		return nil
+	}))
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+
+	_, err = ctx.Encrypt(keys, EncryptAlwaysTrust, plain, cipher)
+	checkError(t, err)
+	if !keywords["END_ENCRYPTION"] {
+		t.Errorf("Expected END_ENCRYPTION status, got %v", keywords)
+	}
+
+	checkError(t, ctx.SetStatusCallback(func(keyword, args string) error {
+		return errors.New("abort")
+	}))
+	_, err = plain.Seek(0, SeekSet)
+	checkError(t, err)
+	if _, err := ctx.Encrypt(keys, EncryptAlwaysTrust, plain, cipher); err == nil {
+		t.Error("Expected status callback error to abort the operation")
+	}
+	checkError(t, ctx.SetStatusCallback(nil))
+}
+
+func TestContext_EncryptInvalidRecipient(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	gen, err := ctx.CreateKey("Sign Only <sign-only@example.com>", "ed25519", time.Time{}, CreateSign|CreateNoPassword|CreateNoExpire)
+	checkError(t, err)
+	signOnly, err := ctx.GetKey(gen.Fingerprint, false)
+	checkError(t, err)
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+
+	res, err := ctx.Encrypt([]*Key{key, signOnly}, EncryptAlwaysTrust, plain, cipher)
+	if err == nil {
+		t.Fatal("Expected encryption to an unusable key to fail")
+	}
+	if res == nil || len(res.InvalidRecipients) != 1 {
+		t.Fatalf("Expected one invalid recipient, got %#v", res)
+	}
+	if invalid := res.InvalidRecipients[0]; invalid.Fingerprint != gen.Fingerprint || invalid.Reason == nil {
+		t.Errorf("Unexpected invalid recipient %#v", invalid)
+	}
+}
+
+func TestContext_EncryptSign(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", true)
+	checkError(t, err)
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+
+	var buf bytes.Buffer
+	cipher, err := NewDataWriter(&buf)
+	checkError(t, err)
+
+	encResult, signResult, err := ctx.EncryptSign([]*Key{key}, []*Key{key}, EncryptAlwaysTrust, plain, cipher)
+	checkError(t, err)
+	if buf.Len() < 1 {
+		t.Error("Expected encrypted bytes, got empty buffer")
+	}
+	if len(encResult.InvalidRecipients) != 0 {
+		t.Errorf("Unexpected invalid recipients %#v", encResult.InvalidRecipients)
+	}
+	if len(signResult.InvalidSigners) != 0 || len(signResult.NewSignatures) != 1 {
+		t.Fatalf("Unexpected sign result %#v", signResult)
+	}
+	sig := signResult.NewSignatures[0]
+	if sig.Fingerprint != "44B646DC347C31E867FF4F450327FFB0229F6136" || sig.Type != SigModeNormal {
+		t.Errorf("Unexpected new signature %#v", sig)
+	}
+}
+
+func TestContext_EncryptSignExt(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", true)
+	checkError(t, err)
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+	encResult, signResult, err := ctx.EncryptSignExt([]string{"test@example.com"}, []*Key{key}, EncryptAlwaysTrust, plain, cipher)
+	checkError(t, err)
+	if len(encResult.InvalidRecipients) != 0 {
+		t.Errorf("Unexpected invalid recipients %#v", encResult.InvalidRecipients)
+	}
+	if len(signResult.InvalidSigners) != 0 || len(signResult.NewSignatures) != 1 {
+		t.Fatalf("Unexpected sign result %#v", signResult)
+	}
+	if fpr := signResult.NewSignatures[0].Fingerprint; fpr != "44B646DC347C31E867FF4F450327FFB0229F6136" {
+		t.Errorf("Unexpected signing key %q", fpr)
+	}
+
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	var buf bytes.Buffer
+	decrypted, err := NewDataWriter(&buf)
+	checkError(t, err)
+	res, err := ctx.DecryptVerify(cipher, decrypted)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte(testData))
+	checkDecryptResult(t, res)
+	_, sigs := ctx.VerifyResult()
+	if len(sigs) != 1 || sigs[0].Status != nil || sigs[0].Fingerprint != "44B646DC347C31E867FF4F450327FFB0229F6136" {
+		t.Errorf("Unexpected signatures %#v", sigs)
+	}
+}
+
+func TestContext_EncryptSymmetric(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, false)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+	checkError(t, ctx.SetNoSymKeyCache(true))
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+	checkError(t, ctx.EncryptSymmetric(plain, cipher))
+
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	var buf bytes.Buffer
+	decrypted, err := NewDataWriter(&buf)
+	checkError(t, err)
+	res, err := ctx.Decrypt(cipher, decrypted)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte(testData))
+	if len(res.Recipients) != 0 {
+		t.Errorf("Unexpected recipients %#v", res.Recipients)
+	}
+}
+
+func TestContext_EncryptPublicKeyAndSymmetric(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+	_, err = ctx.Encrypt([]*Key{key}, EncryptSymmetric, plain, cipher)
+	checkError(t, err)
+
+	// A keyring without the secret key decrypts with the passphrase alone.
+	other, otherHomeDir := ctxWithTempHome(t, false)
+	defer os.RemoveAll(otherHomeDir)
+	defer other.Release()
+
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	var buf bytes.Buffer
+	decrypted, err := NewDataWriter(&buf)
+	checkError(t, err)
+	_, err = other.Decrypt(cipher, decrypted)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte(testData))
+}
+
+func TestContext_EncryptExt(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	var exported bytes.Buffer
+	keyData, err := NewDataWriter(&exported)
+	checkError(t, err)
+	checkError(t, ctx.Export("test@example.com", 0, keyData))
+	keyFile := filepath.Join(homeDir, "recipient.gpg")
+	checkError(t, ioutil.WriteFile(keyFile, exported.Bytes(), 0600))
+
+	for _, v := range []struct {
+		recipients []string
+		keyID      string
+	}{
+		{[]string{"test@example.com"}, "0E3AF7C845E16521"},
+		{[]string{RecipientHidden, "44B646DC347C31E867FF4F450327FFB0229F6136"}, "0000000000000000"},
+		{[]string{RecipientFile, keyFile}, "0E3AF7C845E16521"},
+	} {
+		plain, err := NewDataBytes([]byte(testData))
+		checkError(t, err)
+		cipher, err := NewData()
+		checkError(t, err)
+		res, err := ctx.EncryptExt(v.recipients, EncryptAlwaysTrust, plain, cipher)
+		checkError(t, err)
+		if len(res.InvalidRecipients) != 0 {
+			t.Errorf("Unexpected invalid recipients %#v", res.InvalidRecipients)
+		}
+
+		_, err = cipher.Seek(0, SeekSet)
+		checkError(t, err)
+		var buf bytes.Buffer
+		decrypted, err := NewDataWriter(&buf)
+		checkError(t, err)
+		decResult, err := ctx.Decrypt(cipher, decrypted)
+		checkError(t, err)
+		diff(t, buf.Bytes(), []byte(testData))
+		if len(decResult.Recipients) != 1 || decResult.Recipients[0].KeyID != v.keyID {
+			t.Errorf("Unexpected recipients %#v for %q", decResult.Recipients, v.recipients)
+		}
+	}
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+	// Do not let gpg look up the unknown recipient via WKD or a keyserver.
+	ctx.SetOffline(true)
+	_, err = ctx.EncryptExt([]string{"unknown@example.com"}, EncryptAlwaysTrust, plain, cipher)
+	if err == nil {
+		t.Error("Expected encryption to an unknown recipient to fail")
+	}
+}
+
+func TestCheckEncryptFlags(t *testing.T) {
+	if !versionAtLeast("1.0.0") || versionAtLeast("99.0.0") {
+		t.Errorf("Unexpected version comparison for GPGME %s", Version)
+	}
+	checkError(t, checkEncryptFlags(EncryptAlwaysTrust|EncryptExpectSign))
+	for _, f := range append(encryptFlagVersions, decryptFlagVersions...) {
+		err := checkFlagVersions(f.flag, []flagVersion{f})
+		if supported := versionAtLeast(f.version); supported != (err == nil) {
+			t.Errorf("Unexpected check result for %s with GPGME %s: %v", f.name, Version, err)
+		}
+	}
+}
+
+func TestContext_EncryptNoCompress(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	keys, err := FindKeys("test@example.com", true)
+	checkError(t, err)
+
+	encrypt := func(flags EncryptFlag) int {
+		plain, err := NewDataBytes(bytes.Repeat([]byte(testData), 1024))
+		checkError(t, err)
+		var buf bytes.Buffer
+		cipher, err := NewDataWriter(&buf)
+		checkError(t, err)
+		_, err = ctx.Encrypt(keys, flags, plain, cipher)
+		checkError(t, err)
+		return buf.Len()
+	}
+	compressed := encrypt(EncryptAlwaysTrust)
+	if uncompressed := encrypt(EncryptAlwaysTrust | EncryptNoCompress); uncompressed <= compressed {
+		t.Errorf("Expected uncompressed output to be larger, got %d vs. %d bytes", uncompressed, compressed)
+	}
+}
+
+func TestContext_EncryptThrowKeyIDs(t *testing.T) {
+	if !versionAtLeast("1.8.0") {
+		t.Skip("EncryptThrowKeyIDs requires GPGME 1.8.0 or later")
+	}
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+	_, err = ctx.Encrypt([]*Key{key}, EncryptAlwaysTrust|EncryptThrowKeyIDs, plain, cipher)
+	checkError(t, err)
+
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	decrypted, err := NewData()
+	checkError(t, err)
+	res, err := ctx.Decrypt(cipher, decrypted)
+	checkError(t, err)
+	if len(res.Recipients) != 1 || res.Recipients[0].KeyID != "0000000000000000" {
+		t.Errorf("Expected an anonymous recipient, got %#v", res.Recipients)
+	}
+}
+
+func TestContext_EncryptWrap(t *testing.T) {
+	if !versionAtLeast("1.11.0") {
+		t.Skip("EncryptWrap requires GPGME 1.11.0 or later")
+	}
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", true)
+	checkError(t, err)
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	var signed bytes.Buffer
+	signedData, err := NewDataWriter(&signed)
+	checkError(t, err)
+	_, err = ctx.Sign([]*Key{key}, plain, signedData, SigModeNormal)
+	checkError(t, err)
+
+	// The signed message is encrypted as is, without a literal data packet.
+	wrapped, err := NewDataBytes(signed.Bytes())
+	checkError(t, err)
+	cipher, err := NewData()
+	checkError(t, err)
+	_, err = ctx.Encrypt([]*Key{key}, EncryptAlwaysTrust|EncryptWrap, wrapped, cipher)
+	checkError(t, err)
+
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	var unwrapped bytes.Buffer
+	unwrappedData, err := NewDataWriter(&unwrapped)
+	checkError(t, err)
+	_, err = ctx.DecryptExt(DecryptUnwrap, cipher, unwrappedData)
+	checkError(t, err)
+	diff(t, unwrapped.Bytes(), signed.Bytes())
+
+	sig, err := NewDataBytes(unwrapped.Bytes())
+	checkError(t, err)
+	var buf bytes.Buffer
+	verified, err := NewDataWriter(&buf)
+	checkError(t, err)
+	_, sigs, err := ctx.Verify(sig, nil, verified)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte(testData))
+	if len(sigs) != 1 || sigs[0].Status != nil || sigs[0].Fingerprint != "44B646DC347C31E867FF4F450327FFB0229F6136" {
+		t.Errorf("Unexpected signatures %#v", sigs)
+	}
 }
 
-func TestContext_Encrypt(t *testing.T) {
+func TestContext_EncryptStart(t *testing.T) {
 	ctx, err := New()
 	checkError(t, err)
 
@@ -181,10 +1004,14 @@ func TestContext_Encrypt(t *testing.T) {
 	cipher, err := NewDataWriter(&buf)
 	checkError(t, err)
 
-	checkError(t, ctx.Encrypt(keys, 0, plain, cipher))
+	checkError(t, ctx.EncryptStart(keys, 0, plain, cipher))
+	checkError(t, ctx.Wait())
 	if buf.Len() < 1 {
 		t.Error("Expected encrypted bytes, got empty buffer")
 	}
+	if res := ctx.EncryptResult(); len(res.InvalidRecipients) != 0 {
+		t.Errorf("Unexpected invalid recipients %#v", res.InvalidRecipients)
+	}
 }
 
 func TestContext_Decrypt(t *testing.T) {
@@ -195,8 +1022,126 @@ func TestContext_Decrypt(t *testing.T) {
 	var buf bytes.Buffer
 	plain, err := NewDataWriter(&buf)
 	checkError(t, err)
-	checkError(t, ctx.Decrypt(cipher, plain))
+	res, err := ctx.Decrypt(cipher, plain)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte("Test message\n"))
+	checkDecryptResult(t, res)
+}
+
+func TestContext_DecryptCtx(t *testing.T) {
+	ctx := ctxWithCallback(t)
+
+	cipher, err := NewDataBytes([]byte(testCipherText))
+	checkError(t, err)
+	var buf bytes.Buffer
+	plain, err := NewDataWriter(&buf)
+	checkError(t, err)
+	res, err := ctx.DecryptCtx(context.Background(), cipher, plain)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte("Test message\n"))
+	checkDecryptResult(t, res)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	if _, err := ctx.DecryptCtx(canceled, cipher, plain); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// cancelReader returns the first few bytes of data, then calls cancel and
+// returns the rest, so that the operation is canceled while it is running.
+type cancelReader struct {
+	data   []byte
+	cancel func()
+	calls  int
+}
+
+func (r *cancelReader) Read(p []byte) (int, error) {
+	r.calls++
+	switch {
+	case r.calls == 2:
+		r.cancel()
+		// Give waitContext time to cancel the operation before it can
+		// complete.
+		time.Sleep(100 * time.Millisecond)
+	case len(r.data) == 0:
+		return 0, io.EOF
+	}
+	n := len(r.data)
+	if r.calls == 1 && n > 16 {
+		n = 16
+	}
+	n = copy(p, r.data[:n])
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestContext_DecryptCtxCancel(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cipher, err := NewDataReader(&cancelReader{data: []byte(testCipherText), cancel: cancel})
+	checkError(t, err)
+	var buf bytes.Buffer
+	plain, err := NewDataWriter(&buf)
+	checkError(t, err)
+	if _, err := ctx.DecryptCtx(canceled, cipher, plain); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// The context remains usable after the cancellation.
+	cipher, err = NewDataBytes([]byte(testCipherText))
+	checkError(t, err)
+	buf.Reset()
+	plain, err = NewDataWriter(&buf)
+	checkError(t, err)
+	res, err := ctx.Decrypt(cipher, plain)
+	checkError(t, err)
 	diff(t, buf.Bytes(), []byte("Test message\n"))
+	checkDecryptResult(t, res)
+}
+
+func TestContextError(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// An operation canceled before it runs fails with ErrorCanceled.
+	cipher, err := NewDataBytes([]byte(testCipherText))
+	checkError(t, err)
+	plain, err := NewData()
+	checkError(t, err)
+	checkError(t, ctx.DecryptStart(cipher, plain))
+	checkError(t, ctx.Cancel())
+	err = ctx.Wait()
+	if e, ok := err.(Error); !ok || e.Code() != ErrorCanceled {
+		t.Fatalf("Expected ErrorCanceled, got %v", err)
+	}
+	if e := contextError(canceled, err); e != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", e)
+	}
+	if e := contextError(context.Background(), err); e != err {
+		t.Errorf("Expected %v, got %v", err, e)
+	}
+
+	// Other errors are returned unchanged even if the context is done.
+	cipher, err = NewDataBytes([]byte(testData))
+	checkError(t, err)
+	_, err = ctx.Decrypt(cipher, plain)
+	if err == nil {
+		t.Fatal("Expected decryption of plain text to fail")
+	}
+	if e := contextError(canceled, err); e != err {
+		t.Errorf("Expected %v, got %v", err, e)
+	}
 }
 
 func TestContext_DecryptVerify(t *testing.T) {
@@ -207,8 +1152,105 @@ func TestContext_DecryptVerify(t *testing.T) {
 	var buf bytes.Buffer
 	plain, err := NewDataWriter(&buf)
 	checkError(t, err)
-	checkError(t, ctx.DecryptVerify(cipher, plain))
+	res, err := ctx.DecryptVerify(cipher, plain)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte("Test message\n"))
+	checkDecryptResult(t, res)
+}
+
+// checkDecryptResult verifies the decryption result of the test ciphertexts.
+func checkDecryptResult(t *testing.T, res *DecryptResult) {
+	t.Helper()
+	if res.UnsupportedAlgorithm != "" || res.WrongKeyUsage || res.IsMIME || res.LegacyCipherNoMDC {
+		t.Errorf("Unexpected decrypt result %#v", res)
+	}
+	if res.SymKeyAlgo == "" {
+		t.Errorf("Unexpected symmetric algorithm %q", res.SymKeyAlgo)
+	}
+	if len(res.Recipients) != 1 {
+		t.Fatalf("Expected 1 recipient, got %d", len(res.Recipients))
+	}
+	if r := res.Recipients[0]; r.KeyID != "0E3AF7C845E16521" || r.Status != nil {
+		t.Errorf("Unexpected recipient %#v", r)
+	}
+}
+
+func TestContext_DecryptExt(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	cipher, err := NewDataBytes([]byte(textSignedCipherText))
+	checkError(t, err)
+	var buf bytes.Buffer
+	plain, err := NewDataWriter(&buf)
+	checkError(t, err)
+	res, err := ctx.DecryptExt(DecryptVerify, cipher, plain)
+	checkError(t, err)
+	diff(t, buf.Bytes(), []byte("Test message\n"))
+	checkDecryptResult(t, res)
+	if _, sigs := ctx.VerifyResult(); len(sigs) != 1 || sigs[0].Fingerprint != "44B646DC347C31E867FF4F450327FFB0229F6136" {
+		t.Errorf("Unexpected signatures %#v", sigs)
+	}
+
+	// Unwrapping keeps the inner signed message intact.
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	unwrapped, err := NewData()
+	checkError(t, err)
+	_, err = ctx.DecryptExt(DecryptUnwrap, cipher, unwrapped)
+	checkError(t, err)
+	_, err = unwrapped.Seek(0, SeekSet)
+	checkError(t, err)
+	buf.Reset()
+	plain, err = NewDataWriter(&buf)
+	checkError(t, err)
+	_, sigs, err := ctx.Verify(unwrapped, nil, plain)
+	checkError(t, err)
 	diff(t, buf.Bytes(), []byte("Test message\n"))
+	if len(sigs) != 1 || sigs[0].Status != nil {
+		t.Errorf("Unexpected signatures %#v", sigs)
+	}
+}
+
+func TestContext_EncryptArchive(t *testing.T) {
+	if !versionAtLeast("1.19.0") {
+		t.Skip("EncryptArchive requires GPGME 1.19.0 or later")
+	}
+	if isVersion(t, "2.1.") || isVersion(t, "2.2.") {
+		t.Skip("archives require GnuPG 2.4 or later")
+	}
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	srcDir := filepath.Join(homeDir, "src")
+	outDir := filepath.Join(homeDir, "out")
+	checkError(t, os.Mkdir(srcDir, 0700))
+	checkError(t, os.Mkdir(outDir, 0700))
+	checkError(t, ioutil.WriteFile(filepath.Join(srcDir, "hello.txt"), []byte(testData), 0600))
+
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+	files, err := NewDataBytes([]byte("hello.txt\n"))
+	checkError(t, err)
+	checkError(t, files.SetName(srcDir))
+	cipher, err := NewData()
+	checkError(t, err)
+	_, err = ctx.Encrypt([]*Key{key}, EncryptAlwaysTrust|EncryptArchive, files, cipher)
+	if e, ok := err.(Error); ok && e.Code() == ErrorNotSupported {
+		t.Skip("archives are not supported by the engine")
+	}
+	checkError(t, err)
+
+	_, err = cipher.Seek(0, SeekSet)
+	checkError(t, err)
+	extracted, err := NewData()
+	checkError(t, err)
+	checkError(t, extracted.SetName(outDir))
+	_, err = ctx.DecryptExt(DecryptArchive, cipher, extracted)
+	checkError(t, err)
+	diff(t, mustReadFile(t, filepath.Join(outDir, "hello.txt")), []byte(testData))
 }
 
 func TestContext_Sign(t *testing.T) {
@@ -224,10 +1266,32 @@ func TestContext_Sign(t *testing.T) {
 	signed, err := NewDataWriter(&buf)
 	checkError(t, err)
 
-	checkError(t, ctx.Sign([]*Key{key}, plain, signed, SigModeNormal))
+	res, err := ctx.Sign([]*Key{key}, plain, signed, SigModeNormal)
+	checkError(t, err)
 	if buf.Len() < 1 {
 		t.Error("Expected signed bytes, got empty buffer")
 	}
+	if len(res.InvalidSigners) != 0 {
+		t.Errorf("Unexpected invalid signers %#v", res.InvalidSigners)
+	}
+	if len(res.NewSignatures) != 1 {
+		t.Fatalf("Expected 1 new signature, got %d", len(res.NewSignatures))
+	}
+	sig := res.NewSignatures[0]
+	expectedSig := NewSignature{
+		Type:        SigModeNormal,
+		PubkeyAlgo:  sig.PubkeyAlgo, // Ignore in comparison
+		HashAlgo:    sig.HashAlgo,   // Ignore in comparison
+		Class:       0,
+		Timestamp:   sig.Timestamp, // Ignore in comparison
+		Fingerprint: "44B646DC347C31E867FF4F450327FFB0229F6136",
+	}
+	if sig != expectedSig {
+		t.Errorf("New signature does not match: %#v vs. %#v", sig, expectedSig)
+	}
+	if sig.Timestamp.IsZero() || time.Since(sig.Timestamp) > time.Hour {
+		t.Errorf("Unexpected signature timestamp %v", sig.Timestamp)
+	}
 }
 
 func TestContext_Verify(t *testing.T) {
@@ -256,6 +1320,7 @@ func TestContext_Verify(t *testing.T) {
 		Summary:        SigSumValid | SigSumGreen,
 		Fingerprint:    "44B646DC347C31E867FF4F450327FFB0229F6136",
 		Status:         nil,
+		Notations:      []SigNotation{},
 		Timestamp:      sig.Timestamp,    // Ignore in comparison
 		ExpTimestamp:   sig.ExpTimestamp, // Ignore in comparison
 		WrongKeyUsage:  false,
@@ -266,13 +1331,58 @@ func TestContext_Verify(t *testing.T) {
 		PubkeyAlgo:     sig.PubkeyAlgo, // Ignore in comparison
 		HashAlgo:       sig.HashAlgo,   // Ignore in comparison
 	}
-	if sig != expectedSig {
+	if !reflect.DeepEqual(sig, expectedSig) {
 		t.Errorf("Signature verification does not match: %#v vs. %#v", sig, expectedSig)
 	}
 
 	diff(t, buf.Bytes(), []byte("Test message\n"))
 }
 
+func TestContext_SigNotations(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", true)
+	checkError(t, err)
+
+	checkError(t, ctx.AddSigNotation("build-id@example.com", "1234", SigNotationHumanReadable))
+	checkError(t, ctx.AddSigNotation("", "https://example.com/policy", SigNotationCritical))
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	signed, err := NewData()
+	checkError(t, err)
+	_, err = ctx.Sign([]*Key{key}, plain, signed, SigModeNormal)
+	checkError(t, err)
+	ctx.ClearSigNotations()
+
+	_, err = signed.Seek(0, SeekSet)
+	checkError(t, err)
+	verified, err := NewData()
+	checkError(t, err)
+	_, sigs, err := ctx.Verify(signed, nil, verified)
+	checkError(t, err)
+	if len(sigs) != 1 {
+		t.Fatalf("Expected 1 signature, got %d", len(sigs))
+	}
+	// The order of the notations is up to the engine, and it does not report
+	// the critical flag of policy URLs.
+	expected := map[string]SigNotation{
+		"build-id@example.com": {Name: "build-id@example.com", Value: "1234", Flags: SigNotationHumanReadable},
+		"":                     {Name: "", Value: "https://example.com/policy", Flags: 0},
+	}
+	if len(sigs[0].Notations) != len(expected) {
+		t.Fatalf("Expected %d notations, got %#v", len(expected), sigs[0].Notations)
+	}
+	for _, n := range sigs[0].Notations {
+		if e, ok := expected[n.Name]; !ok || n != e {
+			t.Errorf("Unexpected signature notation %#v", n)
+		}
+		delete(expected, n.Name)
+	}
+}
+
 func TestContext_Import(t *testing.T) {
 	homeDir, err := ioutil.TempDir("", "gpgme-import-test")
 	checkError(t, err)
@@ -326,6 +1436,280 @@ func TestContext_Import(t *testing.T) {
 	}
 }
 
+func TestContext_CreateKey(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, false)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	expires := time.Now().Add(24 * time.Hour)
+	res, err := ctx.CreateKey("Created Key <created@example.com>", "ed25519", expires, CreateSign|CreateCertify|CreateNoPassword)
+	checkError(t, err)
+	if !res.Primary || res.Sub {
+		t.Errorf("Unexpected key generation result %#v", res)
+	}
+
+	key, err := ctx.GetKey(res.Fingerprint, true)
+	checkError(t, err)
+	if uid := key.UserIDs().UID(); uid != "Created Key <created@example.com>" {
+		t.Errorf("Unexpected user ID %q", uid)
+	}
+	if !key.CanSign() || key.SubKeys().Next() != nil {
+		t.Error("Expected a single signing primary key")
+	}
+
+	res, err = ctx.CreateSubKey(key, "cv25519", time.Time{}, CreateEncrypt|CreateNoExpire)
+	checkError(t, err)
+	if res.Primary || !res.Sub {
+		t.Errorf("Unexpected subkey generation result %#v", res)
+	}
+
+	key, err = ctx.GetKey(key.SubKeys().Fingerprint(), true)
+	checkError(t, err)
+	sub := key.SubKeys().Next()
+	if sub == nil || sub.Fingerprint() != res.Fingerprint {
+		t.Fatal("Expected the new subkey on the key")
+	}
+	if !sub.Expires().IsZero() {
+		t.Errorf("Expected non-expiring subkey, got %v", sub.Expires())
+	}
+
+	if _, err := ctx.CreateKey("Expired <expired@example.com>", "", time.Now().Add(-time.Hour), 0); err == nil {
+		t.Error("Expected an error for an expiration time in the past")
+	}
+}
+
+func TestContext_UserIDs(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	const (
+		oldUID = "Test Key <test@example.com>"
+		newUID = "Test Key <new@example.com>"
+	)
+	key, err := ctx.GetKey("test@example.com", true)
+	checkError(t, err)
+
+	checkError(t, ctx.AddUID(key, newUID))
+	checkError(t, ctx.SetUIDFlag(key, newUID, UIDFlagPrimary, ""))
+	checkError(t, ctx.RevokeUID(key, oldUID))
+
+	key, err = ctx.GetKey("44B646DC347C31E867FF4F450327FFB0229F6136", false)
+	checkError(t, err)
+	uids := map[string]*UserID{}
+	for u := key.UserIDs(); u != nil; u = u.Next() {
+		uids[u.UID()] = u
+	}
+	if u := uids[newUID]; u == nil || u.Revoked() {
+		t.Errorf("Expected valid user ID %q", newUID)
+	}
+	if u := uids[oldUID]; u == nil || !u.Revoked() {
+		t.Errorf("Expected revoked user ID %q", oldUID)
+	}
+	if uid := key.UserIDs().UID(); uid != newUID {
+		t.Errorf("Expected primary user ID %q, got %q", newUID, uid)
+	}
+}
+
+func TestContext_SignKey(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	res, err := ctx.CreateKey("Test CA <ca@example.com>", "ed25519", time.Time{}, CreateCertify|CreateNoPassword|CreateNoExpire)
+	checkError(t, err)
+	ca, err := ctx.GetKey(res.Fingerprint, true)
+	checkError(t, err)
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+
+	checkError(t, ctx.SetSigners([]*Key{ca}))
+	checkError(t, ctx.SignKey(key, []string{"Test Key <test@example.com>"}, time.Time{}, KeySignNoExpire))
+
+	checkError(t, ctx.SetKeyListMode(KeyListModeLocal|KeyListModeSigs))
+	key, err = ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+	var sig *KeySig
+	for s := key.UserIDs().Signatures(); s != nil; s = s.Next() {
+		if s.KeyID() == ca.SubKeys().KeyID() {
+			sig = s
+		}
+	}
+	if sig == nil {
+		t.Fatal("Expected a certification by the new key")
+	}
+	if sig.Status() != nil || sig.Revoked() || !sig.Exportable() || sig.Email() != "ca@example.com" {
+		t.Errorf("Unexpected certification %v %v %v %q", sig.Status(), sig.Revoked(), sig.Exportable(), sig.Email())
+	}
+	if sig.PubkeyAlgo() != PubkeyAlgoEdDSA || sig.Created().IsZero() || !sig.Expires().IsZero() {
+		t.Errorf("Unexpected certification %v %v %v", sig.PubkeyAlgo(), sig.Created(), sig.Expires())
+	}
+
+	checkError(t, ctx.RevokeSignature(key, ca, nil, 0))
+	key, err = ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+	var revocation *KeySig
+	for s := key.UserIDs().Signatures(); s != nil; s = s.Next() {
+		if s.KeyID() == ca.SubKeys().KeyID() && s.Revoked() {
+			revocation = s
+		}
+	}
+	if revocation == nil {
+		t.Fatal("Expected the certification by the new key to be revoked")
+	}
+	if revocation.SigClass() != 0x30 || revocation.Status() != nil {
+		t.Errorf("Unexpected revocation %#x %v", revocation.SigClass(), revocation.Status())
+	}
+}
+
+func TestContext_SetTofuPolicy(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	checkError(t, ctx.SetTrustModel("tofu+pgp"))
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+	checkError(t, ctx.SetTofuPolicy(key, TofuPolicyBad))
+
+	checkError(t, ctx.SetKeyListMode(KeyListModeLocal|KeyListModeWithTOFU))
+	checkError(t, ctx.KeyListStart("test@example.com", false))
+	if !ctx.KeyListNext() {
+		t.Fatalf("Expected a key, got %v", ctx.KeyError)
+	}
+	info := ctx.Key.UserIDs().TOFU()
+	for ctx.KeyListNext() {
+	}
+	checkError(t, ctx.KeyError)
+	checkError(t, ctx.KeyListEnd())
+
+	if info == nil {
+		t.Fatal("Expected TOFU information")
+	}
+	if info.Policy != TofuPolicyBad {
+		t.Errorf("Unexpected TOFU policy %v", info.Policy)
+	}
+	if info.SignCount != 0 || info.EncrCount != 0 || !info.SignFirst.IsZero() || !info.EncrLast.IsZero() {
+		t.Errorf("Unexpected TOFU history %+v", info)
+	}
+}
+
+func TestContext_SetExpire(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, false)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	res, err := ctx.CreateKey("Expiring <expiring@example.com>", "ed25519", time.Time{}, CreateCertify|CreateNoPassword|CreateNoExpire)
+	checkError(t, err)
+	key, err := ctx.GetKey(res.Fingerprint, true)
+	checkError(t, err)
+	sub, err := ctx.CreateSubKey(key, "cv25519", time.Now().Add(24*time.Hour), CreateEncrypt|CreateNoPassword)
+	checkError(t, err)
+
+	expires := time.Now().Add(30 * 24 * time.Hour)
+	checkError(t, ctx.SetExpire(key, expires, nil))
+	checkError(t, ctx.SetExpire(key, time.Time{}, []string{sub.Fingerprint}))
+
+	key, err = ctx.GetKey(res.Fingerprint, true)
+	checkError(t, err)
+	if d := key.SubKeys().Expires().Sub(expires); d < -time.Minute || d > time.Minute {
+		t.Errorf("Unexpected primary key expiration %v, expected %v", key.SubKeys().Expires(), expires)
+	}
+	if exp := key.SubKeys().Next().Expires(); !exp.IsZero() {
+		t.Errorf("Expected subkey not to expire, got %v", exp)
+	}
+	if err := ctx.SetExpire(key, time.Now().Add(-time.Hour), nil); err == nil {
+		t.Error("Expected error for an expiration time in the past")
+	}
+}
+
+func TestContext_SetOwnerTrust(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	for _, trust := range []Validity{ValidityMarginal, ValidityFull} {
+		key, err := ctx.GetKey("test@example.com", false)
+		checkError(t, err)
+		checkError(t, ctx.SetOwnerTrust(key, trust))
+		key, err = ctx.GetKey("test@example.com", false)
+		checkError(t, err)
+		if key.OwnerTrust() != trust {
+			t.Errorf("Unexpected owner trust %v, expected %v", key.OwnerTrust(), trust)
+		}
+	}
+}
+
+func TestContext_Interact(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+
+	out, err := NewData()
+	checkError(t, err)
+	defer out.Close()
+	var prompts []string
+	err = ctx.Interact(key, 0, func(status, args string, w io.Writer) error {
+		if w == nil {
+			return nil
+		}
+		prompts = append(prompts, status+" "+args)
+		if len(prompts) == 1 {
+			_, err := io.WriteString(w, "showpref\n")
+			return err
+		}
+		_, err := io.WriteString(w, "quit\n")
+		return err
+	}, out)
+	checkError(t, err)
+	if !reflect.DeepEqual(prompts, []string{"GET_LINE keyedit.prompt", "GET_LINE keyedit.prompt"}) {
+		t.Errorf("Unexpected prompts %q", prompts)
+	}
+	_, err = out.Seek(0, 0)
+	checkError(t, err)
+	output, err := ioutil.ReadAll(out)
+	checkError(t, err)
+	if !bytes.Contains(output, []byte("fpr:::::::::44B646DC347C31E867FF4F450327FFB0229F6136:")) {
+		t.Errorf("Expected the key listing in output, got %q", output)
+	}
+
+	abort := errors.New("abort")
+	err = ctx.Interact(key, 0, func(status, args string, w io.Writer) error {
+		if w != nil {
+			return abort
+		}
+		return nil
+	}, nil)
+	if err != abort {
+		t.Errorf("Expected callback error, got %v", err)
+	}
+}
+
+func TestContext_Delete(t *testing.T) {
+	ctx, homeDir := ctxWithTempHome(t, true)
+	defer os.RemoveAll(homeDir)
+	defer ctx.Release()
+
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+
+	err = ctx.Delete(key, 0)
+	if e, ok := err.(Error); !ok || e.Code() != ErrorConflict {
+		t.Errorf("Expected secret key conflict, got %v", err)
+	}
+	checkError(t, ctx.Delete(key, DeleteAllowSecret|DeleteForce))
+	if _, err := ctx.GetKey("test@example.com", false); err == nil {
+		t.Error("Expected key to be deleted")
+	}
+	err = ctx.Delete(key, DeleteAllowSecret|DeleteForce)
+	if e, ok := err.(Error); !ok || e.Code() != ErrorNoPublicKey {
+		t.Errorf("Expected missing key error, got %v", err)
+	}
+}
+
 func TestContext_Export(t *testing.T) {
 	ctx, err := New()
 	checkError(t, err)
@@ -452,6 +1836,13 @@ func checkError(t testing.TB, err error) {
 	t.Fatal(err)
 }
 
+func mustReadFile(t testing.TB, name string) []byte {
+	t.Helper()
+	b, err := ioutil.ReadFile(name)
+	checkError(t, err)
+	return b
+}
+
 func absTestGPGHome() string {
 	f, err := filepath.Abs(testGPGHome)
 	if err != nil {